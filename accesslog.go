@@ -0,0 +1,140 @@
+package slog
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const clfTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// AccessLogInput parses log lines in the Common Log Format and the Combined
+// Log Format of web servers such as Apache and nginx:
+//
+//	127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /index.html HTTP/1.0" 200 2326 "http://example.com/" "Mozilla/5.0"
+//
+// The request line is stored in the mesg field and the other values in the fields
+// ip, user, method, path, proto, status, bytes, referer and ua. Values that are
+// logged as a dash are omitted. The time is converted to UTC if log.LUTC is set
+// and requires log.Ldate and log.Ltime to be written. The prefix is ignored.
+// It is meant for NewReader:
+//
+//	r := slog.NewReader(os.Stdin, "", log.LstdFlags|log.LUTC|slog.Lmessage, slog.AccessLogInput())
+func AccessLogInput() Option {
+	return func(l *logwriter) {
+		l.input = parseAccessLog
+	}
+}
+
+// nextToken returns the text up to the next space and the text after it.
+func nextToken(s string) (string, string, bool) {
+	i := strings.IndexByte(s, ' ')
+	if i < 1 {
+		return "", "", false
+	}
+	return s[:i], s[i+1:], true
+}
+
+// nextQuoted returns the unquoted string at the start of s and the text after it.
+// Quotes inside the string are escaped with backslashes.
+func nextQuoted(s string) (string, string, bool) {
+	if len(s) == 0 || s[0] != '"' {
+		return "", "", false
+	}
+	escaped := false
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\':
+			escaped = true
+			i++
+		case s[i] == '"':
+			if !escaped {
+				return s[1:i], strings.TrimPrefix(s[i+1:], " "), true
+			}
+			if v, err := strconv.Unquote(s[:i+1]); err == nil {
+				return v, strings.TrimPrefix(s[i+1:], " "), true
+			}
+			return s[1:i], strings.TrimPrefix(s[i+1:], " "), true
+		}
+	}
+	return "", "", false
+}
+
+func appendDashed(fields []Field, key, val string) []Field {
+	if val == "-" || val == "" {
+		return fields
+	}
+	return append(fields, Field{key, StringValue(val)})
+}
+
+func parseAccessLog(l *logwriter, e *Entry, text string) error {
+	fields := e.Fields[:0]
+	*e = Entry{}
+
+	text = strings.TrimRightFunc(text, unicode.IsSpace)
+	e.Raw = text
+
+	ip, rest, ok1 := nextToken(text)
+	_, rest, ok2 := nextToken(rest)
+	user, rest, ok3 := nextToken(rest)
+	if !ok1 || !ok2 || !ok3 || !strings.HasPrefix(rest, "[") {
+		return ErrMalformed
+	}
+	i := strings.IndexByte(rest, ']')
+	if i == -1 {
+		return ErrMalformed
+	}
+	t, err := time.Parse(clfTimeLayout, rest[1:i])
+	if err != nil {
+		return ErrMalformed
+	}
+	if l.flags&log.LUTC != 0 {
+		t = t.UTC()
+	}
+	request, rest, ok := nextQuoted(strings.TrimPrefix(rest[i+1:], " "))
+	if !ok {
+		return ErrMalformed
+	}
+	status, rest, ok := nextToken(rest + " ")
+	if !ok {
+		return ErrMalformed
+	}
+	code, ok := atoiFixed(status)
+	if !ok {
+		return ErrMalformed
+	}
+	size, rest, ok := nextToken(rest + " ")
+	if !ok {
+		return ErrMalformed
+	}
+	rest = strings.TrimSuffix(rest, " ")
+
+	e.Time, e.Message = t, request
+	fields = appendDashed(fields, "ip", ip)
+	fields = appendDashed(fields, "user", user)
+	if parts := strings.Split(request, " "); len(parts) == 3 {
+		fields = append(fields,
+			Field{"method", StringValue(parts[0])},
+			Field{"path", StringValue(parts[1])},
+			Field{"proto", StringValue(parts[2])})
+	}
+	fields = append(fields, Field{"status", IntValue(int64(code))})
+	if n, ok := atoiFixed(size); ok {
+		fields = append(fields, Field{"bytes", IntValue(int64(n))})
+	} else if size != "-" {
+		return ErrMalformed
+	}
+
+	// combined log format
+	if referer, rest, ok := nextQuoted(rest); ok {
+		fields = appendDashed(fields, "referer", referer)
+		if ua, _, ok := nextQuoted(rest); ok {
+			fields = appendDashed(fields, "ua", ua)
+		}
+	}
+
+	e.Fields = fields
+	return nil
+}
@@ -0,0 +1,28 @@
+package slog
+
+import (
+	"io"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestAccessLogInput(t *testing.T) {
+	in := `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326` + "\n" +
+		`::1 - - [10/Oct/2000:13:55:37 +0000] "POST /login HTTP/1.1" 302 - "https://example.com/" "Mozilla/5.0 \"x\""` + "\n" +
+		`10.0.0.1 - - [10/Oct/2000:13:55:38 +0000] "-" 408 0 "-" "-"` + "\n" +
+		"not an access log line\n"
+
+	b, err := io.ReadAll(NewReader(strings.NewReader(in), "", log.LstdFlags|log.LUTC|Lmessage, AccessLogInput()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := `{"time":"2000-10-10T20:55:36Z","mesg":"GET /apache_pb.gif HTTP/1.0","ip":"127.0.0.1","user":"frank","method":"GET","path":"/apache_pb.gif","proto":"HTTP/1.0","status":200,"bytes":2326}` + "\n" +
+		`{"time":"2000-10-10T13:55:37Z","mesg":"POST /login HTTP/1.1","ip":"::1","method":"POST","path":"/login","proto":"HTTP/1.1","status":302,"referer":"https://example.com/","ua":"Mozilla/5.0 \"x\""}` + "\n" +
+		`{"time":"2000-10-10T13:55:38Z","mesg":"-","ip":"10.0.0.1","status":408,"bytes":0}` + "\n" +
+		`{"mesg":"not an access log line"}` + "\n"
+	if string(b) != exp {
+		t.Fatal(string(b))
+	}
+}
@@ -0,0 +1,157 @@
+package slog
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// AdminOptions lists the settings that are controlled by the admin handler.
+// Settings that are nil are not exposed.
+type AdminOptions struct {
+	// Level is the level of MinLevel.
+	Level *LevelVar
+
+	// Samplers are the named sampling rates of Sampling.
+	Samplers map[string]*SampleVar
+
+	// Sinks are the named outputs that can be turned on and off.
+	Sinks map[string]*Switch
+
+	// Components are the levels of MinLevelByComponent.
+	Components *ComponentLevels
+}
+
+type adminState struct {
+	Level      string            `json:"level,omitempty"`
+	Sampling   map[string]int    `json:"sampling,omitempty"`
+	Sinks      map[string]bool   `json:"sinks,omitempty"`
+	Components map[string]string `json:"components,omitempty"`
+}
+
+type adminHandler struct {
+	opts AdminOptions
+}
+
+func (h *adminHandler) state() adminState {
+	var s adminState
+	if h.opts.Level != nil {
+		s.Level = h.opts.Level.Level().String()
+	}
+	if len(h.opts.Samplers) > 0 {
+		s.Sampling = make(map[string]int, len(h.opts.Samplers))
+		for name, v := range h.opts.Samplers {
+			s.Sampling[name] = v.Rate()
+		}
+	}
+	if len(h.opts.Sinks) > 0 {
+		s.Sinks = make(map[string]bool, len(h.opts.Sinks))
+		for name, sw := range h.opts.Sinks {
+			s.Sinks[name] = sw.Enabled()
+		}
+	}
+	if h.opts.Components != nil {
+		levels := h.opts.Components.Levels()
+		s.Components = make(map[string]string, len(levels))
+		for component, level := range levels {
+			s.Components[component] = level.String()
+		}
+	}
+	return s
+}
+
+// apply validates the requested changes before applying them,
+// so that an invalid request changes nothing.
+func (h *adminHandler) apply(s adminState) (int, string) {
+	var level Level
+	if s.Level != "" {
+		var ok bool
+		if level, ok = ParseLevel(s.Level); !ok || h.opts.Level == nil {
+			return http.StatusBadRequest, "unknown level " + s.Level
+		}
+	}
+	for name := range s.Sampling {
+		if h.opts.Samplers[name] == nil {
+			return http.StatusNotFound, "unknown sampler " + name
+		}
+	}
+	for name := range s.Sinks {
+		if h.opts.Sinks[name] == nil {
+			return http.StatusNotFound, "unknown sink " + name
+		}
+	}
+	components := make(map[string]Level, len(s.Components))
+	for component, name := range s.Components {
+		if h.opts.Components == nil {
+			return http.StatusNotFound, "unknown component " + component
+		}
+		if name == "" {
+			continue
+		}
+		level, ok := ParseLevel(name)
+		if !ok {
+			return http.StatusBadRequest, "unknown level " + name
+		}
+		components[component] = level
+	}
+
+	if s.Level != "" {
+		h.opts.Level.Set(level)
+	}
+	for name, rate := range s.Sampling {
+		h.opts.Samplers[name].Set(rate)
+	}
+	for name, on := range s.Sinks {
+		h.opts.Sinks[name].Enable(on)
+	}
+	for component := range s.Components {
+		if level, ok := components[component]; ok {
+			h.opts.Components.Set(component, level)
+		} else {
+			h.opts.Components.Delete(component)
+		}
+	}
+	return http.StatusOK, ""
+}
+
+func (h *adminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var s adminState
+		if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if status, msg := h.apply(s); status != http.StatusOK {
+			http.Error(w, msg, status)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(h.state())
+}
+
+// NewAdminHandler creates an HTTP handler that reads and changes the logging
+// settings of a running program. GET responds with the current settings
+// as a JSON object, for example:
+//
+//	{"level":"info","sampling":{"access":10},"sinks":{"slack":true}}
+//
+// PUT accepts an object of the same form that lists the settings to change,
+// and responds with the new settings. For example, to enable debug logging:
+//
+//	curl -X PUT -d '{"level":"debug"}' localhost:6060/debug/log
+//
+// The levels of components are changed individually and removed by an empty level:
+//
+//	curl -X PUT -d '{"components":{"db":"debug","http":""}}' localhost:6060/debug/log
+//
+// The handler must not be exposed publicly.
+func NewAdminHandler(opts AdminOptions) http.Handler {
+	return &adminHandler{opts}
+}
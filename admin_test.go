@@ -0,0 +1,59 @@
+package slog
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAdminHandler(t *testing.T) {
+	var level LevelVar
+	var rate SampleVar
+	sink := NewSwitch(&bytes.Buffer{})
+	h := NewAdminHandler(AdminOptions{
+		Level:    &level,
+		Samplers: map[string]*SampleVar{"access": &rate},
+		Sinks:    map[string]*Switch{"slack": sink},
+	})
+
+	do := func(method, body string) (int, string) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, "/", strings.NewReader(body)))
+		return rec.Code, strings.TrimSpace(rec.Body.String())
+	}
+
+	if code, body := do("GET", ""); code != http.StatusOK || body != `{"level":"info","sampling":{"access":0},"sinks":{"slack":true}}` {
+		t.Fatal(code, body)
+	}
+
+	code, body := do("PUT", `{"level":"debug","sampling":{"access":10},"sinks":{"slack":false}}`)
+	if code != http.StatusOK || body != `{"level":"debug","sampling":{"access":10},"sinks":{"slack":false}}` {
+		t.Fatal(code, body)
+	}
+	if level.Level() != LevelDebug || rate.Rate() != 10 || sink.Enabled() {
+		t.Fatal("settings not applied")
+	}
+
+	if code, _ := do("PUT", `{"level":"error","sinks":{"nope":true}}`); code != http.StatusNotFound || level.Level() != LevelDebug {
+		t.Fatal(code, level.Level())
+	}
+	if code, _ := do("PUT", `{"level":"loud"}`); code != http.StatusBadRequest {
+		t.Fatal(code)
+	}
+	var components ComponentLevels
+	components.Set("http", LevelWarn)
+	h = NewAdminHandler(AdminOptions{Components: &components})
+	code, body = do("PUT", `{"components":{"db":"debug","http":""}}`)
+	if code != http.StatusOK || body != `{"components":{"db":"debug"}}` {
+		t.Fatal(code, body)
+	}
+	if code, _ := do("PUT", `{"components":{"db":"loud"}}`); code != http.StatusBadRequest || components.Level("db") != LevelDebug {
+		t.Fatal(code)
+	}
+
+	if code, _ := do("DELETE", ""); code != http.StatusMethodNotAllowed {
+		t.Fatal(code)
+	}
+}
@@ -0,0 +1,333 @@
+package slog
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// slot is a cell of the ring buffer. Its sequence number tells
+// producers and the consumer whether the cell is free or full.
+type slot struct {
+	seq uint64
+	buf []byte
+}
+
+// ring is a bounded lock-free multi-producer multi-consumer queue
+// based on the array queue of Dmitry Vyukov.
+type ring struct {
+	head  uint64
+	_     [56]byte
+	tail  uint64
+	_     [56]byte
+	mask  uint64
+	slots []slot
+}
+
+func newRing(capacity int) *ring {
+	n := 1
+	for n < capacity {
+		n <<= 1
+	}
+	r := &ring{mask: uint64(n - 1), slots: make([]slot, n)}
+	for i := range r.slots {
+		r.slots[i].seq = uint64(i)
+	}
+	return r
+}
+
+// push copies p into the queue. It returns false if the queue is full.
+func (r *ring) push(p []byte) bool {
+	pos := atomic.LoadUint64(&r.tail)
+	for {
+		s := &r.slots[pos&r.mask]
+		seq := atomic.LoadUint64(&s.seq)
+		switch diff := int64(seq) - int64(pos); {
+		case diff == 0:
+			if atomic.CompareAndSwapUint64(&r.tail, pos, pos+1) {
+				s.buf = append(s.buf[:0], p...)
+				atomic.StoreUint64(&s.seq, pos+1)
+				return true
+			}
+			pos = atomic.LoadUint64(&r.tail)
+		case diff < 0:
+			return false
+		default:
+			pos = atomic.LoadUint64(&r.tail)
+		}
+	}
+}
+
+// pop calls fn with the oldest element of the queue. It returns false if the queue is empty.
+// The slice passed to fn must not be retained. Producers may call pop to discard
+// the oldest element to make room for a new one.
+func (r *ring) pop(fn func([]byte)) bool {
+	pos := atomic.LoadUint64(&r.head)
+	for {
+		s := &r.slots[pos&r.mask]
+		seq := atomic.LoadUint64(&s.seq)
+		switch diff := int64(seq) - int64(pos+1); {
+		case diff == 0:
+			if atomic.CompareAndSwapUint64(&r.head, pos, pos+1) {
+				fn(s.buf)
+				atomic.StoreUint64(&s.seq, pos+r.mask+1)
+				return true
+			}
+			pos = atomic.LoadUint64(&r.head)
+		case diff < 0:
+			return false
+		default:
+			pos = atomic.LoadUint64(&r.head)
+		}
+	}
+}
+
+// len returns the approximate number of elements in the queue.
+func (r *ring) len() int {
+	head := atomic.LoadUint64(&r.head)
+	tail := atomic.LoadUint64(&r.tail)
+	if tail < head {
+		return 0
+	}
+	return int(tail - head)
+}
+
+// Overflow is the policy of an AsyncWriter when its queue is full.
+type Overflow int
+
+// Overflow policies.
+const (
+	// DropNewest drops the entries that do not fit in the queue.
+	DropNewest Overflow = iota
+	// DropOldest discards the oldest queued entries to make room for new ones.
+	DropOldest
+	// Block blocks the caller until there is room in the queue.
+	Block
+	// Sample keeps only one in SampleRate entries while the queue is
+	// more than three quarters full, and drops the entries that do not fit.
+	Sample
+)
+
+// AsyncOptions configures an AsyncWriter.
+type AsyncOptions struct {
+	// Capacity is the number of entries that the queue holds,
+	// rounded up to a power of two. Defaults to 1024.
+	Capacity int
+
+	// Overflow is the policy when the queue is full. Defaults to DropNewest.
+	Overflow Overflow
+
+	// SampleRate is the sampling rate of the Sample policy. Defaults to 10.
+	SampleRate int
+
+	// Drops counts the entries that are not written, if not nil.
+	Drops *DropCounter
+}
+
+// AsyncStats counts the entries that were not written by an AsyncWriter.
+type AsyncStats struct {
+	// Dropped is the number of entries that did not fit in the queue.
+	Dropped uint64
+	// Discarded is the number of queued entries that were discarded by DropOldest.
+	Discarded uint64
+	// Sampled is the number of entries that were skipped by Sample.
+	Sampled uint64
+}
+
+// AsyncWriter decouples logging goroutines from a slow output writer.
+// Entries are copied into a bounded lock-free queue and written by a
+// background goroutine, so that producers never wait on each other or on the output,
+// unless the Block policy is selected.
+// Entries that are queued at the same time are written to the output
+// with a single call to Write, which saves system calls for file and socket outputs.
+type AsyncWriter struct {
+	dropped   uint64
+	discarded uint64
+	sampled   uint64
+	nsampled  uint64
+	sleeping  uint32
+	closed    uint32
+	q         *ring
+	w         io.Writer
+	opts      AsyncOptions
+	wake      chan struct{}
+	space     chan struct{}
+	done      chan struct{}
+	closeMu   sync.Mutex
+	err       error
+}
+
+// NewAsyncWriter creates an AsyncWriter that writes to w.
+// Close must be called to write the remaining entries and stop the background goroutine.
+func NewAsyncWriter(w io.Writer, opts AsyncOptions) *AsyncWriter {
+	if opts.Capacity <= 0 {
+		opts.Capacity = 1024
+	}
+	if opts.SampleRate <= 0 {
+		opts.SampleRate = 10
+	}
+	a := &AsyncWriter{
+		q:     newRing(opts.Capacity),
+		w:     w,
+		opts:  opts,
+		wake:  make(chan struct{}, 1),
+		space: make(chan struct{}, 1),
+		done:  make(chan struct{}),
+	}
+	go a.loop()
+	return a
+}
+
+// maxBatch is the size in bytes at which the consumer stops
+// collecting queued entries and writes them.
+const maxBatch = 64 << 10
+
+func (a *AsyncWriter) loop() {
+	defer close(a.done)
+
+	var batch []byte
+	collect := func(p []byte) {
+		batch = append(batch, p...)
+	}
+
+	// drain collects the queued entries and writes them with a single call.
+	drain := func() bool {
+		batch = batch[:0]
+		for len(batch) < maxBatch && a.q.pop(collect) {
+		}
+		if len(batch) == 0 {
+			return false
+		}
+		select {
+		case a.space <- struct{}{}:
+		default:
+		}
+		if _, err := a.w.Write(batch); err != nil && a.err == nil {
+			a.err = err
+		}
+		return true
+	}
+
+	for {
+		if drain() {
+			continue
+		}
+
+		// announce that the consumer is going to sleep and check again
+		// to avoid missing an entry pushed in between
+		atomic.StoreUint32(&a.sleeping, 1)
+		if drain() {
+			atomic.StoreUint32(&a.sleeping, 0)
+			continue
+		}
+
+		if atomic.LoadUint32(&a.closed) != 0 {
+			return
+		}
+
+		<-a.wake
+	}
+}
+
+func (a *AsyncWriter) signal() {
+	if atomic.CompareAndSwapUint32(&a.sleeping, 1, 0) {
+		select {
+		case a.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Unwrap returns the output writer.
+func (a *AsyncWriter) Unwrap() io.Writer { return a.w }
+
+// Write copies p into the queue. It does not block unless the Block policy is selected.
+// Entries written after Close are dropped.
+func (a *AsyncWriter) Write(p []byte) (int, error) {
+	if atomic.LoadUint32(&a.closed) != 0 {
+		a.drop(&a.dropped, DropOverflow, p)
+		return len(p), nil
+	}
+
+	if a.opts.Overflow == Sample && a.q.len() >= len(a.q.slots)*3/4 {
+		if atomic.AddUint64(&a.nsampled, 1)%uint64(a.opts.SampleRate) != 0 {
+			a.drop(&a.sampled, DropSampled, p)
+			return len(p), nil
+		}
+	}
+
+	for !a.q.push(p) {
+		switch a.opts.Overflow {
+		case DropOldest:
+			a.q.pop(func(old []byte) {
+				a.drop(&a.discarded, DropOverflow, old)
+			})
+		case Block:
+			a.signal()
+			select {
+			case <-a.space:
+			case <-a.done:
+				a.drop(&a.dropped, DropOverflow, p)
+				return len(p), nil
+			}
+		default:
+			a.drop(&a.dropped, DropOverflow, p)
+			return len(p), nil
+		}
+	}
+
+	a.signal()
+	return len(p), nil
+}
+
+func (a *AsyncWriter) drop(counter *uint64, reason string, p []byte) {
+	atomic.AddUint64(counter, 1)
+	if a.opts.Drops != nil {
+		level, _ := lineLevel(p)
+		a.opts.Drops.Add(reason, level)
+	}
+}
+
+// Dropped returns the total number of entries that were not written.
+func (a *AsyncWriter) Dropped() uint64 {
+	stats := a.Stats()
+	return stats.Dropped + stats.Discarded + stats.Sampled
+}
+
+// Stats returns the number of entries that were not written by reason.
+func (a *AsyncWriter) Stats() AsyncStats {
+	return AsyncStats{
+		Dropped:   atomic.LoadUint64(&a.dropped),
+		Discarded: atomic.LoadUint64(&a.discarded),
+		Sampled:   atomic.LoadUint64(&a.sampled),
+	}
+}
+
+// Close writes the queued entries and stops the background goroutine.
+// Entries written concurrently with Close may be dropped.
+// It returns the first error returned by the output writer.
+func (a *AsyncWriter) Close() error {
+	return a.Drain(context.Background())
+}
+
+// Drain is like Close, but gives up waiting for the queued entries
+// to be written when the context is done and returns its error.
+func (a *AsyncWriter) Drain(ctx context.Context) error {
+	a.closeMu.Lock()
+	if atomic.CompareAndSwapUint32(&a.closed, 0, 1) {
+		atomic.StoreUint32(&a.sleeping, 0)
+		select {
+		case a.wake <- struct{}{}:
+		default:
+		}
+	}
+	a.closeMu.Unlock()
+
+	select {
+	case <-a.done:
+		return a.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
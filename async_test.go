@@ -0,0 +1,174 @@
+package slog
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAsyncWriter(t *testing.T) {
+	var b bytes.Buffer
+	a := NewAsyncWriter(&b, AsyncOptions{})
+	l := New(a, "", Lmessage)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l.Println("hello")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	} else if n := strings.Count(b.String(), "{\"mesg\":\"hello\"}\n"); n+int(a.Dropped()) != 800 {
+		t.Fatal(n, a.Dropped())
+	}
+}
+
+type blockingWriter struct {
+	release chan struct{}
+	entered chan struct{}
+	bytes.Buffer
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	if w.entered != nil {
+		select {
+		case w.entered <- struct{}{}:
+		default:
+		}
+	}
+	<-w.release
+	return w.Buffer.Write(p)
+}
+
+func TestAsyncWriterDrops(t *testing.T) {
+	w := blockingWriter{release: make(chan struct{})}
+	a := NewAsyncWriter(&w, AsyncOptions{Capacity: 2})
+	for i := 0; i < 10; i++ {
+		_, _ = a.Write([]byte("x\n"))
+	}
+	close(w.release)
+
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	} else if n := strings.Count(w.String(), "x\n"); n+int(a.Dropped()) != 10 || n < 2 {
+		t.Fatal(n, a.Dropped())
+	}
+}
+
+func TestRing(t *testing.T) {
+	r := newRing(3)
+	if len(r.slots) != 4 {
+		t.Fatal(len(r.slots))
+	}
+	for i := 0; i < 4; i++ {
+		if !r.push([]byte{byte(i)}) {
+			t.Fatal(i)
+		}
+	}
+	if r.push([]byte{4}) {
+		t.Fatal()
+	}
+	for i := 0; i < 4; i++ {
+		if !r.pop(func(p []byte) {
+			if p[0] != byte(i) {
+				t.Fatal(p)
+			}
+		}) {
+			t.Fatal(i)
+		}
+	}
+	if r.pop(func([]byte) {}) {
+		t.Fatal()
+	}
+}
+
+type countingWriter struct {
+	writes int
+	bytes.Buffer
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+func TestAsyncWriterBatches(t *testing.T) {
+	w := blockingWriter{release: make(chan struct{})}
+	var c countingWriter
+	a := NewAsyncWriter(io.MultiWriter(&w, &c), AsyncOptions{Capacity: 16})
+
+	// the first write blocks the consumer, so that the others are queued
+	_, _ = a.Write([]byte("a\n"))
+	for i := 0; i < 5; i++ {
+		_, _ = a.Write([]byte("b\n"))
+	}
+	close(w.release)
+
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	} else if c.String() != "a\nb\nb\nb\nb\nb\n" || c.writes > 2 {
+		t.Fatal(c.String(), c.writes)
+	}
+}
+
+func TestAsyncWriterOverflow(t *testing.T) {
+	for _, testCase := range []struct {
+		Overflow Overflow
+		Written  int
+		Stats    AsyncStats
+	}{
+		{DropNewest, 5, AsyncStats{Dropped: 5}},
+		{DropOldest, 5, AsyncStats{Discarded: 5}},
+		{Sample, 5, AsyncStats{Dropped: 2, Sampled: 3}},
+	} {
+		w := blockingWriter{release: make(chan struct{}), entered: make(chan struct{}, 1)}
+		a := NewAsyncWriter(&w, AsyncOptions{Capacity: 4, Overflow: testCase.Overflow, SampleRate: 2})
+
+		// the consumer blocks on the first entry, so the queue holds four more
+		_, _ = a.Write([]byte("0\n"))
+		select {
+		case <-w.entered:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the consumer did not take the first entry")
+		}
+		for i := 1; i < 10; i++ {
+			_, _ = a.Write([]byte{byte('0' + i), '\n'})
+		}
+		close(w.release)
+
+		if err := a.Close(); err != nil {
+			t.Fatal(err)
+		} else if n := strings.Count(w.String(), "\n"); n != testCase.Written {
+			t.Fatal(testCase.Overflow, w.String())
+		} else if a.Stats() != testCase.Stats {
+			t.Fatal(testCase.Overflow, a.Stats())
+		}
+
+		if testCase.Overflow == DropOldest && w.String() != "0\n6\n7\n8\n9\n" {
+			t.Fatal(w.String())
+		}
+	}
+}
+
+func TestAsyncWriterBlock(t *testing.T) {
+	var b bytes.Buffer
+	a := NewAsyncWriter(&b, AsyncOptions{Capacity: 2, Overflow: Block})
+	for i := 0; i < 100; i++ {
+		_, _ = a.Write([]byte("x\n"))
+	}
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	} else if n := strings.Count(b.String(), "x\n"); n != 100 || a.Dropped() != 0 {
+		t.Fatal(n, a.Dropped())
+	}
+}
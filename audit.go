@@ -0,0 +1,102 @@
+package slog
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash/crc32"
+)
+
+// Errors returned by VerifyHMAC.
+var (
+	ErrNoSignature  = errors.New("slog: entry is not signed")
+	ErrBadSignature = errors.New("slog: entry signature mismatch")
+)
+
+const hmacField = `"hmac":"`
+
+// sealComma appends the comma that separates a sealed field from the
+// preceding fields, if there are any.
+func sealComma(dst []byte) []byte {
+	if dst[len(dst)-1] == '{' {
+		return dst
+	}
+	return append(dst, ',')
+}
+
+// trimSeal removes the trailing sealed field with a hex encoded value of n digits
+// from the line, if it ends with one, so that the seal before it can be verified.
+func trimSeal(line []byte, field string, n int) []byte {
+	i := len(line) - len(field) - n - 2
+	if i < 1 || !bytes.HasPrefix(line[i:], []byte(field)) || !bytes.HasSuffix(line, []byte(`"}`)) {
+		return line
+	}
+	if line[i-1] == ',' {
+		i--
+	}
+	return append(line[:i:i], '}')
+}
+
+// SignHMAC appends an hmac field to every entry containing the hex encoded
+// HMAC-SHA256 of the serialized entry, computed with the given key.
+// The hmac field is the last field, unless Checksum is registered after SignHMAC,
+// in which case the crc32 field follows and covers it. Signed entries should not be
+// colorized, because the signature covers the color codes.
+func SignHMAC(key []byte) Option {
+	key = append([]byte(nil), key...)
+	return func(l *logwriter) {
+		mac := hmac.New(sha256.New, key)
+		l.seals = append(l.seals, func(dst []byte, start int) []byte {
+			mac.Reset()
+			_, _ = mac.Write(dst[start:])
+			var sum [sha256.Size]byte
+			dst = append(sealComma(dst), hmacField...)
+			dst = hexAppend(dst, mac.Sum(sum[:0]))
+			return append(dst, '"')
+		})
+	}
+}
+
+func hexAppend(dst, src []byte) []byte {
+	n := len(dst)
+	dst = append(dst, make([]byte, hex.EncodedLen(len(src)))...)
+	hex.Encode(dst[n:], src)
+	return dst
+}
+
+// VerifyHMAC verifies the hmac field of an entry produced by a writer
+// configured with SignHMAC. A leading sd-daemon priority, a trailing crc32
+// field and trailing white space are ignored.
+func VerifyHMAC(line, key []byte) error {
+	line = bytes.TrimRight(line, " \t\r\n")
+	if i := bytes.IndexByte(line, '{'); i != -1 {
+		line = line[i:]
+	}
+	line = trimSeal(line, crcField, 2*crc32.Size)
+
+	const sumLen = 2 * sha256.Size
+	n := len(line) - len(hmacField) - sumLen - 2
+	if n < 1 || !bytes.HasPrefix(line[n:], []byte(hmacField)) || !bytes.HasSuffix(line, []byte(`"}`)) {
+		return ErrNoSignature
+	}
+
+	want := make([]byte, sha256.Size)
+	if _, err := hex.Decode(want, line[n+len(hmacField):len(line)-2]); err != nil {
+		return ErrBadSignature
+	}
+
+	// the signed entry ends before the separating comma, if any
+	signed := line[:n]
+	if line[n-1] == ',' {
+		signed = line[:n-1]
+	}
+
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(signed)
+	if !hmac.Equal(mac.Sum(nil), want) {
+		return ErrBadSignature
+	}
+	return nil
+}
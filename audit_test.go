@@ -0,0 +1,45 @@
+package slog
+
+import (
+	"bytes"
+	"log"
+	"testing"
+)
+
+func TestSignHMAC(t *testing.T) {
+	key := []byte("secret")
+
+	var b bytes.Buffer
+	l := New(&b, "", log.LstdFlags|Lmessage|Lparsefields|Lpriority, SignHMAC(key))
+	l.Println("level=info transfer amount=100")
+
+	line := b.Bytes()
+	if err := VerifyHMAC(line, key); err != nil {
+		t.Fatal(err, string(line))
+	} else if err := VerifyHMAC(line, []byte("wrong")); err != ErrBadSignature {
+		t.Fatal(err)
+	}
+
+	tampered := bytes.Replace(line, []byte("100"), []byte("900"), 1)
+	if err := VerifyHMAC(tampered, key); err != ErrBadSignature {
+		t.Fatal(err)
+	} else if err := VerifyHMAC([]byte(`{"mesg":"x"}`), key); err != ErrNoSignature {
+		t.Fatal(err)
+	}
+}
+
+func TestSignHMACNoFields(t *testing.T) {
+	key := []byte("secret")
+
+	var b bytes.Buffer
+	l := New(&b, "", 0, SignHMAC(key))
+	l.Print("hello")
+
+	line := b.Bytes()
+	if !bytes.HasPrefix(line, []byte(`{"hmac":"`)) {
+		t.Fatal(string(line))
+	}
+	if err := VerifyHMAC(line, key); err != nil {
+		t.Fatal(err, string(line))
+	}
+}
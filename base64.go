@@ -0,0 +1,40 @@
+package slog
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+const base64Tag = "b64:"
+
+// Base64 formats binary data as a key-value pair whose value is the standard
+// base64 encoding of data tagged with b64:, such that the Base64Values option
+// recognizes it. Spaces and equals signs in the key are replaced by underscores.
+//
+//	log.Print("received ", slog.Base64("payload", data))
+func Base64(key string, data []byte) string {
+	return KV(key, base64Tag+base64.StdEncoding.EncodeToString(data))
+}
+
+// Base64Values recognizes values tagged with b64: that hold valid standard base64,
+// such as payload=b64:SGVsbG8=. The tag is removed from the value and a field
+// with the key followed by .encoding and the value base64 is added after it,
+// so that consumers know to decode it. Values that are not valid base64 are left as is.
+func Base64Values() Option {
+	return BeforeWrite(func(e *Entry) bool {
+		for i := 0; i < len(e.Fields); i++ {
+			f := &e.Fields[i]
+			if f.Value.kind != KindString || !strings.HasPrefix(f.Value.str, base64Tag) {
+				continue
+			}
+			data := f.Value.str[len(base64Tag):]
+			if _, err := base64.StdEncoding.DecodeString(data); err != nil {
+				continue
+			}
+			f.Value = StringValue(data)
+			e.insert(i+1, Field{f.Key + ".encoding", StringValue("base64")})
+			i++
+		}
+		return true
+	})
+}
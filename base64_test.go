@@ -0,0 +1,19 @@
+package slog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBase64Values(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields, Base64Values())
+	l.Print(Base64("data", []byte("Hello\x00")), " bad=b64:*** n=1")
+
+	if want := `{"data":"SGVsbG8A","data.encoding":"base64","bad":"b64:***","n":1}` + "\n"; b.String() != want {
+		t.Fatal(b.String())
+	}
+	if s := Base64("pay load", []byte("Hi")); s != "pay_load=\"b64:SGk=\"" {
+		t.Fatal(s)
+	}
+}
@@ -0,0 +1,218 @@
+package slog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrRecordTooLarge is returned by batching writers when an entry exceeds
+// the maximum size of a single record of the destination.
+var ErrRecordTooLarge = errors.New("slog: record too large")
+
+const (
+	// maxPending limits the number of batches that are kept while the destination fails.
+	// Entries that do not fit are dropped.
+	maxPending = 8
+
+	// maxAttempts limits the number of times that a batch is sent,
+	// so that a batch that is rejected for good does not block the others.
+	maxAttempts = 3
+)
+
+// flushErrors combines the errors of several flushes.
+type flushErrors []error
+
+func (e flushErrors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+// joinErr combines two errors, either of which may be nil.
+func joinErr(err, next error) error {
+	if err == nil {
+		return next
+	} else if next == nil {
+		return err
+	} else if errs, ok := err.(flushErrors); ok {
+		return append(errs, next)
+	}
+	return flushErrors{err, next}
+}
+
+type batch struct {
+	recs     [][]byte
+	attempts int
+}
+
+// batcher accumulates log lines and flushes them in batches
+// when the count or size limits are reached or the interval elapses.
+// Batches are sent outside of the lock, so that writers are not held up
+// by a slow destination unless they complete a batch. Failed batches are
+// kept and sent again, before the newer batches, at the next flush.
+type batcher struct {
+	mu       sync.Mutex
+	recs     [][]byte
+	size     int
+	pending  []batch
+	dropped  int
+	err      error
+	sending  sync.Mutex
+	maxRecs  int
+	maxBytes int
+	maxRec   int
+	flush    func([][]byte) error
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func newBatcher(maxRecs, maxBytes, maxRec int, interval time.Duration, flush func([][]byte) error) *batcher {
+	b := &batcher{
+		maxRecs:  maxRecs,
+		maxBytes: maxBytes,
+		maxRec:   maxRec,
+		flush:    flush,
+		done:     make(chan struct{}),
+	}
+	if interval > 0 {
+		b.wg.Add(1)
+		go b.loop(interval)
+	}
+	return b
+}
+
+func (b *batcher) loop(interval time.Duration) {
+	defer b.wg.Done()
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			b.mu.Lock()
+			b.sealLocked()
+			b.mu.Unlock()
+			if err := b.send(); err != nil {
+				b.mu.Lock()
+				b.err = joinErr(b.err, err)
+				b.mu.Unlock()
+			}
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// sealLocked moves the current batch to the pending batches.
+func (b *batcher) sealLocked() {
+	if len(b.recs) == 0 {
+		return
+	} else if len(b.pending) < maxPending {
+		b.pending = append(b.pending, batch{recs: b.recs})
+	} else {
+		b.dropped += len(b.recs)
+	}
+	b.recs, b.size = nil, 0
+}
+
+// send sends the pending batches in order until one fails.
+func (b *batcher) send() error {
+	b.sending.Lock()
+	defer b.sending.Unlock()
+	for {
+		b.mu.Lock()
+		if len(b.pending) == 0 {
+			b.mu.Unlock()
+			return nil
+		}
+		recs := b.pending[0].recs
+		b.mu.Unlock()
+
+		err := b.flush(recs)
+
+		// only send removes batches, so pending[0] is still recs
+		b.mu.Lock()
+		if err == nil {
+			b.pending = b.pending[1:]
+		} else if b.pending[0].attempts++; b.pending[0].attempts >= maxAttempts {
+			b.dropped += len(recs)
+			b.pending = b.pending[1:]
+		}
+		b.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// takeErrLocked returns and clears the errors of asynchronous flushes
+// and the number of dropped entries.
+func (b *batcher) takeErrLocked() error {
+	err := b.err
+	b.err = nil
+	if b.dropped > 0 {
+		err = joinErr(err, fmt.Errorf("slog: %d entries dropped after failed sends", b.dropped))
+		b.dropped = 0
+	}
+	return err
+}
+
+// Write copies p into the current batch and sends the batch if it is full.
+// It returns the errors of previous flushes, if any.
+func (b *batcher) Write(p []byte) (int, error) {
+	if len(p) > b.maxRec {
+		return 0, ErrRecordTooLarge
+	}
+
+	b.mu.Lock()
+	err := b.takeErrLocked()
+	full := len(b.recs) > 0 && b.size+len(p) > b.maxBytes
+	if full {
+		b.sealLocked()
+	}
+	b.recs = append(b.recs, append([]byte(nil), p...))
+	b.size += len(p)
+	if len(b.recs) >= b.maxRecs || b.size >= b.maxBytes {
+		b.sealLocked()
+		full = true
+	}
+	b.mu.Unlock()
+
+	if full {
+		err = joinErr(err, b.send())
+	}
+	return len(p), err
+}
+
+// Flush sends the current batch and the batches that failed before.
+func (b *batcher) Flush() error {
+	b.mu.Lock()
+	b.sealLocked()
+	b.mu.Unlock()
+	err := b.send()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return joinErr(b.takeErrLocked(), err)
+}
+
+// Close stops the interval flusher and flushes the current batch.
+func (b *batcher) Close() error {
+	select {
+	case <-b.done:
+	default:
+		close(b.done)
+	}
+	b.wg.Wait()
+	return b.Flush()
+}
+
+// Drain is like Close, but gives up when the context is done.
+func (b *batcher) Drain(ctx context.Context) error {
+	return drainFunc(ctx, b.Close)
+}
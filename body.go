@@ -0,0 +1,94 @@
+package slog
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultBodyTypes are the content types of the bodies that are logged
+// if no allowlist is configured. A type ending in a slash matches all subtypes.
+var DefaultBodyTypes = []string{"application/json", "application/x-www-form-urlencoded", "text/"}
+
+// bodyTypeAllowed reports whether the content type matches one of the types.
+func bodyTypeAllowed(contentType string, types []string) bool {
+	if types == nil {
+		types = DefaultBodyTypes
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, t := range types {
+		if mediaType == t || strings.HasSuffix(t, "/") && strings.HasPrefix(mediaType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// bodyCapture keeps the first limit bytes of a body.
+type bodyCapture struct {
+	buf   []byte
+	limit int
+	total int64
+}
+
+func (c *bodyCapture) capture(p []byte) {
+	c.total += int64(len(p))
+	if n := c.limit - len(c.buf); n > 0 {
+		if len(p) > n {
+			p = p[:n]
+		}
+		c.buf = append(c.buf, p...)
+	}
+}
+
+// field formats the captured body as a key-value pair preceded by a space,
+// or returns the empty string if nothing was captured. Truncated bodies end with an ellipsis.
+func (c *bodyCapture) field(key string) string {
+	if c == nil || c.total == 0 {
+		return ""
+	}
+	s := c.buf
+	for len(s) > 0 && !utf8.Valid(s) {
+		s = s[:len(s)-1]
+	}
+	text := string(s)
+	if c.total > int64(len(s)) {
+		text += "..."
+	}
+	return " " + key + "=" + quoteValue(text)
+}
+
+type captureReader struct {
+	io.ReadCloser
+	c *bodyCapture
+}
+
+func (r *captureReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.c.capture(p[:n])
+	return n, err
+}
+
+// peekBody captures the first limit bytes of body and returns a body
+// that reads the same bytes as the original.
+func peekBody(body io.ReadCloser, limit int) (io.ReadCloser, *bodyCapture) {
+	c := &bodyCapture{limit: limit}
+	head := make([]byte, limit+1)
+	n, err := io.ReadFull(body, head)
+	c.capture(head[:n])
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		// the body has been read completely
+		return readCloser{bytes.NewReader(head[:n]), body}, c
+	}
+	return readCloser{io.MultiReader(bytes.NewReader(head[:n]), body), body}, c
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,124 @@
+package slog
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// BreakerOptions configures a circuit breaker.
+type BreakerOptions struct {
+	// Failures is the number of consecutive failed writes that open the breaker.
+	// Defaults to five.
+	Failures int
+
+	// Probe is the interval at which an open breaker tries the output writer
+	// again. The breaker closes when a probe succeeds. Defaults to ten seconds.
+	Probe time.Duration
+
+	// Fallback receives the entries while the breaker is open.
+	// The entries are dropped if nil.
+	Fallback io.Writer
+
+	// Drops counts the entries that are dropped while the breaker is open, if not nil.
+	Drops *DropCounter
+
+	// OnChange is called when the breaker opens or closes, if not nil.
+	OnChange func(open bool, err error)
+
+	// Clock tells the time used to schedule the probes. Defaults to SystemClock.
+	Clock Clock
+}
+
+// Breaker is a circuit breaker around a writer that fails repeatedly, such as
+// a dead collector. While the breaker is open, entries are routed to the fallback
+// writer instead of waiting for the output writer to fail, so that the
+// application is not stalled. It is safe for concurrent use.
+type Breaker struct {
+	w     io.Writer
+	opts  BreakerOptions
+	clock Clock
+
+	mu       sync.Mutex
+	failures int
+	open     bool
+	probe    time.Time
+}
+
+// NewBreaker creates a circuit breaker that writes to w.
+//
+//	w, _ := slog.Dial("tcp", "collector:5170", slog.NetOptions{})
+//	b := slog.NewBreaker(w, slog.BreakerOptions{Fallback: os.Stderr})
+//	l := slog.New(b, "", slog.LstdFlags)
+func NewBreaker(w io.Writer, opts BreakerOptions) *Breaker {
+	if opts.Failures <= 0 {
+		opts.Failures = 5
+	}
+	if opts.Probe <= 0 {
+		opts.Probe = 10 * time.Second
+	}
+	return &Breaker{w: w, opts: opts, clock: clockOrSystem(opts.Clock)}
+}
+
+// Write writes p to the output writer, or to the fallback writer
+// if the breaker is open or the write opens it.
+func (b *Breaker) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock.Now()
+	if b.open && now.Before(b.probe) {
+		return b.fallback(p)
+	}
+
+	n, err := b.w.Write(p)
+	if err == nil {
+		b.failures = 0
+		if b.open {
+			b.open = false
+			b.changed(nil)
+		}
+		return n, nil
+	}
+
+	b.failures++
+	if !b.open && b.failures < b.opts.Failures {
+		return n, err
+	}
+	b.probe = now.Add(b.opts.Probe)
+	if !b.open {
+		b.open = true
+		b.changed(err)
+	}
+	return b.fallback(p)
+}
+
+func (b *Breaker) fallback(p []byte) (int, error) {
+	if b.opts.Fallback != nil {
+		return b.opts.Fallback.Write(p)
+	}
+	if b.opts.Drops != nil {
+		level, ok := lineLevel(p)
+		if !ok {
+			level = LevelInfo
+		}
+		b.opts.Drops.Add(DropBreaker, level)
+	}
+	return len(p), nil
+}
+
+func (b *Breaker) changed(err error) {
+	if b.opts.OnChange != nil {
+		b.opts.OnChange(b.open, err)
+	}
+}
+
+// Open reports whether the breaker is open.
+func (b *Breaker) Open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.open
+}
+
+// Unwrap returns the output writer.
+func (b *Breaker) Unwrap() io.Writer { return b.w }
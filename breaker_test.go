@@ -0,0 +1,76 @@
+package slog
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+type flakyWriter struct {
+	bytes.Buffer
+	err    error
+	writes int
+}
+
+func (w *flakyWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.err != nil {
+		return 0, w.err
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestBreaker(t *testing.T) {
+	clock := &stepClock{t: time.Unix(0, 0)}
+	w := &flakyWriter{err: errors.New("down")}
+	var fallback bytes.Buffer
+	var changes []bool
+	b := NewBreaker(w, BreakerOptions{
+		Failures: 2,
+		Probe:    time.Second,
+		Fallback: &fallback,
+		OnChange: func(open bool, err error) { changes = append(changes, open) },
+		Clock:    clock,
+	})
+
+	if _, err := b.Write([]byte("a\n")); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := b.Write([]byte("b\n")); err != nil || !b.Open() {
+		t.Fatal(err)
+	}
+	_, _ = b.Write([]byte("c\n"))
+	if w.writes != 2 || fallback.String() != "b\nc\n" {
+		t.Fatal(w.writes, fallback.String())
+	}
+
+	// a failed probe keeps the breaker open
+	clock.t = clock.t.Add(time.Second)
+	_, _ = b.Write([]byte("d\n"))
+	if w.writes != 3 || !b.Open() || fallback.String() != "b\nc\nd\n" {
+		t.Fatal(w.writes, fallback.String())
+	}
+
+	// a successful probe closes it
+	w.err = nil
+	clock.t = clock.t.Add(time.Second)
+	_, _ = b.Write([]byte("e\n"))
+	if b.Open() || w.String() != "e\n" {
+		t.Fatal(w.String())
+	}
+	if len(changes) != 2 || !changes[0] || changes[1] {
+		t.Fatal(changes)
+	}
+}
+
+func TestBreakerDrops(t *testing.T) {
+	drops := NewDropCounter()
+	b := NewBreaker(&flakyWriter{err: errors.New("down")}, BreakerOptions{Failures: 1, Drops: drops})
+	if _, err := b.Write([]byte(`{"levl":"warn"}` + "\n")); err != nil {
+		t.Fatal(err)
+	}
+	if drops.Total() != 1 || drops.take()[dropKey{DropBreaker, LevelWarn}] != 1 {
+		t.Fatal(drops.Total())
+	}
+}
@@ -0,0 +1,95 @@
+package slog
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// callerFunc returns the name of the function that called the standard logger.
+// It walks the stack up to the frames of package log and returns the first
+// function outside of it that does not log on behalf of its caller.
+func callerFunc() string {
+	var pcs [32]uintptr
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+
+	// skip counts the frames of skipFrames, which is one more
+	// than the number of helper functions above Output.
+	var seenLog bool
+	var skip int
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, "log.") {
+			seenLog = true
+		} else if frame.Function == skipFramesFunc {
+			skip++
+		} else if seenLog && !wrapperFuncs[frame.Function] {
+			if skip <= 1 {
+				return trimFuncPath(frame.Function)
+			}
+			skip--
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// trimFuncPath trims the package path from a qualified function name.
+func trimFuncPath(fn string) string {
+	if i := strings.LastIndexByte(fn, '/'); i != -1 {
+		return fn[i+1:]
+	}
+	return fn
+}
+
+var skipFramesFunc = runtime.FuncForPC(reflect.ValueOf(skipFrames).Pointer()).Name()
+
+// skipFrames calls l.Output through n nested calls of itself, so that callerFunc
+// learns the number of helper functions to skip from the stack of each call.
+func skipFrames(l *log.Logger, n, calldepth int, s string) error {
+	if n > 0 {
+		return skipFrames(l, n-1, calldepth+1, s)
+	}
+	return l.Output(calldepth, s)
+}
+
+func outputDepth(l *log.Logger, calldepth int, s string) error {
+	if l == nil {
+		l = Default()
+	}
+	if calldepth < 1 {
+		calldepth = 1
+	}
+	return skipFrames(l, calldepth-1, calldepth+3, s)
+}
+
+// Output writes s to l like l.Output, for helper functions that log on behalf
+// of their callers. The calldepth is the number of frames to skip, where 1 is
+// the caller of Output. Unlike l.Output, the skipped functions are also skipped
+// by Lfuncname, so that the file, line and function name all point at the real
+// call site. The default logger is used if l is nil.
+//
+//	func logError(l *log.Logger, err error) {
+//		slog.Output(l, 2, slog.KV("error", err.Error()))
+//	}
+func Output(l *log.Logger, calldepth int, s string) error {
+	return outputDepth(l, calldepth, s)
+}
+
+// Outputf is like Output and formats the message like Printf.
+func Outputf(l *log.Logger, calldepth int, format string, v ...interface{}) error {
+	return outputDepth(l, calldepth, fmt.Sprintf(format, v...))
+}
+
+// Outputw is like Output and formats the message and key-value pairs like Printw.
+func Outputw(l *log.Logger, calldepth int, msg string, kv ...interface{}) error {
+	return outputDepth(l, calldepth, formatw(0, msg, kv))
+}
+
+func init() {
+	registerWrappers(Output, Outputf, Outputw, outputDepth)
+}
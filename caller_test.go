@@ -0,0 +1,71 @@
+package slog
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestLfuncname(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lfuncname|Lmessage)
+	l.Println("hello")
+	if exp := "{\"func\":\"slog.TestLfuncname\",\"mesg\":\"hello\"}\n"; b.String() != exp {
+		t.Fatal(b.String())
+	}
+
+	b.Reset()
+	func() { l.Printf("world") }()
+	if exp := "{\"func\":\"slog.TestLfuncname.func1\",\"mesg\":\"world\"}\n"; b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
+
+func logHelper(l *log.Logger, msg string) {
+	_ = Outputw(l, 2, msg, "a", 1)
+}
+
+func TestOutput(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", log.Lshortfile|Lfuncname|Lmessage|Lparsefields)
+	_, _, line, _ := runtime.Caller(0)
+	logHelper(l, "hello")
+	exp := fmt.Sprintf(`{"fnam":"caller_test.go","flno":%d,"func":"slog.TestOutput","mesg":"hello a=1","a":1}`+"\n", line+1)
+	if b.String() != exp {
+		t.Fatal(b.String())
+	}
+
+	b.Reset()
+	_ = Output(l, 1, "world")
+	if !strings.Contains(b.String(), `"func":"slog.TestOutput"`) {
+		t.Fatal(b.String())
+	}
+}
+
+func logDepth(l *log.Logger, calldepth int) {
+	_ = Output(l, calldepth, "hello")
+}
+
+func logDepthOuter(l *log.Logger, calldepth int) {
+	logDepth(l, calldepth)
+}
+
+func TestOutputDepthPerCall(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lfuncname)
+
+	// a function skipped by one call is not skipped by the next
+	logDepthOuter(l, 3)
+	logDepthOuter(l, 2)
+	logDepthOuter(l, 1)
+
+	exp := "{\"func\":\"slog.TestOutputDepthPerCall\"}\n" +
+		"{\"func\":\"slog.logDepthOuter\"}\n" +
+		"{\"func\":\"slog.logDepth\"}\n"
+	if b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
@@ -0,0 +1,59 @@
+package slog
+
+import "sync"
+
+// HighCardinality is the value that replaces the values of a field beyond its cardinality limit.
+const HighCardinality = "<high-cardinality>"
+
+// CardinalityOptions configures LimitCardinality.
+type CardinalityOptions struct {
+	// Keys are the fields whose distinct values are tracked.
+	Keys []string
+
+	// Max is the number of distinct values per field that pass unchanged.
+	Max int
+
+	// Replace transforms the values beyond the limit. Use HashSHA256 to keep
+	// them distinguishable. Defaults to replacing them by HighCardinality.
+	Replace Transformer
+}
+
+// LimitCardinality tracks the distinct values of the fields with the given keys
+// and replaces the values that are not among the first opts.Max values seen,
+// preventing unbounded field explosion in downstream index stores.
+// The value in the message is replaced as well.
+func LimitCardinality(opts CardinalityOptions) Option {
+	if opts.Replace == nil {
+		opts.Replace = func(Value) Value { return StringValue(HighCardinality) }
+	}
+	var mu sync.Mutex
+	seen := make(map[string]map[string]struct{}, len(opts.Keys))
+	for _, key := range opts.Keys {
+		seen[key] = map[string]struct{}{}
+	}
+
+	return BeforeWrite(func(e *Entry) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		for i := range e.Fields {
+			f := &e.Fields[i]
+			values, ok := seen[f.Key]
+			if !ok {
+				continue
+			}
+			s := f.Value.String()
+			if _, ok := values[s]; ok {
+				continue
+			}
+			if len(values) < opts.Max {
+				// copy the value, which may refer to the log line
+				values[string(append([]byte(nil), s...))] = struct{}{}
+				continue
+			}
+			val := opts.Replace(f.Value)
+			e.Message = replaceFieldText(e.Message, f.Key, f.Value, val)
+			f.Value = val
+		}
+		return true
+	})
+}
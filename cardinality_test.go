@@ -0,0 +1,23 @@
+package slog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLimitCardinality(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields, LimitCardinality(CardinalityOptions{Keys: []string{"user"}, Max: 2}))
+	for _, user := range []string{"ann", "bob", "ann", "cid", "bob"} {
+		l.Print("user=", user, " other=", user)
+	}
+
+	want := `{"user":"ann","other":"ann"}` + "\n" +
+		`{"user":"bob","other":"bob"}` + "\n" +
+		`{"user":"ann","other":"ann"}` + "\n" +
+		`{"user":"<high-cardinality>","other":"cid"}` + "\n" +
+		`{"user":"bob","other":"bob"}` + "\n"
+	if b.String() != want {
+		t.Fatal(b.String())
+	}
+}
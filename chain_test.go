@@ -0,0 +1,50 @@
+package slog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHashChain(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lmessage, HashChain(2))
+	l.Println("one")
+	l.Println("two")
+	l.Println("three")
+
+	lines := strings.Split(b.String(), "\n")
+	if len(lines) != 5 || !strings.HasPrefix(lines[2], `{"anchr":"`) {
+		t.Fatal(b.String())
+	} else if !strings.HasPrefix(lines[0], `{"mesg":"one","seqn":1,"prev":""}`) {
+		t.Fatal(lines[0])
+	}
+
+	if n, err := VerifyChain(strings.NewReader(b.String())); err != nil || n != 3 {
+		t.Fatal(n, err)
+	}
+
+	tampered := strings.Replace(b.String(), "two", "2", 1)
+	if n, err := VerifyChain(strings.NewReader(tampered)); err == nil || n != 2 {
+		t.Fatal(n, err)
+	}
+
+	removed := strings.Join(append(lines[:1:1], lines[3:]...), "\n")
+	if n, err := VerifyChain(strings.NewReader(removed)); err == nil || n != 1 {
+		t.Fatal(n, err)
+	}
+}
+
+func TestHashChainNoFields(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", 0, HashChain(0))
+	l.Println("one")
+	l.Println("two")
+
+	if !strings.HasPrefix(b.String(), `{"seqn":1,"prev":""}`+"\n") {
+		t.Fatal(b.String())
+	}
+	if n, err := VerifyChain(&b); err != nil || n != 2 {
+		t.Fatal(n, err)
+	}
+}
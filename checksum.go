@@ -0,0 +1,67 @@
+package slog
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash/crc32"
+)
+
+// Errors returned by VerifyChecksum.
+var (
+	ErrNoChecksum  = errors.New("slog: entry has no checksum")
+	ErrBadChecksum = errors.New("slog: entry checksum mismatch")
+)
+
+const crcField = `"crc32":"`
+
+// Checksum appends a crc32 field to every entry containing the hex encoded
+// IEEE CRC-32 of the serialized entry, so that shipping pipelines can cheaply
+// detect truncated and corrupted lines. Unlike SignHMAC, the checksum does not
+// protect against tampering. The crc32 field is the last field, unless SignHMAC
+// is registered after Checksum, in which case the hmac field follows and covers it.
+func Checksum() Option {
+	return func(l *logwriter) {
+		l.seals = append(l.seals, func(dst []byte, start int) []byte {
+			sum := crc32.ChecksumIEEE(dst[start:])
+			dst = append(sealComma(dst), crcField...)
+			dst = hexAppend(dst, []byte{byte(sum >> 24), byte(sum >> 16), byte(sum >> 8), byte(sum)})
+			return append(dst, '"')
+		})
+	}
+}
+
+// VerifyChecksum verifies the crc32 field of an entry produced by a writer
+// configured with Checksum. A leading sd-daemon priority, a trailing hmac
+// field and trailing white space are ignored.
+func VerifyChecksum(line []byte) error {
+	line = bytes.TrimRight(line, " \t\r\n")
+	if i := bytes.IndexByte(line, '{'); i != -1 {
+		line = line[i:]
+	}
+	line = trimSeal(line, hmacField, 2*sha256.Size)
+
+	const sumLen = 2 * crc32.Size
+	n := len(line) - len(crcField) - sumLen - 2
+	if n < 1 || !bytes.HasPrefix(line[n:], []byte(crcField)) || !bytes.HasSuffix(line, []byte(`"}`)) {
+		return ErrNoChecksum
+	}
+
+	var want [crc32.Size]byte
+	if _, err := hex.Decode(want[:], line[n+len(crcField):len(line)-2]); err != nil {
+		return ErrBadChecksum
+	}
+
+	// the checksummed entry ends before the separating comma, if any
+	checked := line[:n]
+	if line[n-1] == ',' {
+		checked = line[:n-1]
+	}
+
+	sum := crc32.ChecksumIEEE(checked)
+	if want != [crc32.Size]byte{byte(sum >> 24), byte(sum >> 16), byte(sum >> 8), byte(sum)} {
+		return ErrBadChecksum
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package slog
+
+import (
+	"bytes"
+	"log"
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", log.LstdFlags|Lmessage|Lparsefields|Lpriority, Checksum())
+	l.Println("level=info transfer amount=100")
+
+	line := b.Bytes()
+	if err := VerifyChecksum(line); err != nil {
+		t.Fatal(err, string(line))
+	}
+
+	corrupted := bytes.Replace(line, []byte("100"), []byte("900"), 1)
+	if err := VerifyChecksum(corrupted); err != ErrBadChecksum {
+		t.Fatal(err)
+	} else if err := VerifyChecksum(line[:len(line)/2]); err != ErrNoChecksum {
+		t.Fatal(err)
+	}
+
+	b.Reset()
+	l = New(&b, "", Lmessage, Checksum())
+	l.Print("hello")
+	if exp := `{"mesg":"hello","crc32":"dcc1bd5f"}` + "\n"; b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
+
+func TestChecksumNoFields(t *testing.T) {
+	var b bytes.Buffer
+	New(&b, "", 0, Checksum()).Print("hello")
+	if !bytes.HasPrefix(b.Bytes(), []byte(`{"crc32":"`)) {
+		t.Fatal(b.String())
+	}
+	if err := VerifyChecksum(b.Bytes()); err != nil {
+		t.Fatal(err, b.String())
+	}
+}
+
+func TestChecksumWithHMAC(t *testing.T) {
+	key := []byte("secret")
+	for _, opts := range [][]Option{
+		{SignHMAC(key), Checksum()},
+		{Checksum(), SignHMAC(key)},
+	} {
+		var b bytes.Buffer
+		New(&b, "", Lmessage, opts...).Print("hello")
+		if err := VerifyChecksum(b.Bytes()); err != nil {
+			t.Fatal(err, b.String())
+		} else if err := VerifyHMAC(b.Bytes(), key); err != nil {
+			t.Fatal(err, b.String())
+		}
+
+		corrupted := bytes.Replace(b.Bytes(), []byte("hello"), []byte("hallo"), 1)
+		if err := VerifyChecksum(corrupted); err != ErrBadChecksum {
+			t.Fatal(err)
+		} else if err := VerifyHMAC(corrupted, key); err != ErrBadSignature {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package slog
+
+import "time"
+
+// Clock tells the current time. Slog uses it for the time stamps,
+// identifiers and intervals that it generates itself, so that tests
+// and replay tools can produce deterministic output.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// SystemClock is the clock that slog uses unless configured otherwise.
+var SystemClock Clock = systemClock{}
+
+func clockOrSystem(c Clock) Clock {
+	if c == nil {
+		return SystemClock
+	}
+	return c
+}
+
+// WithClock replaces the time stamps of the entries with the time told by
+// the clock. It is also used by options such as EntryID that generate values
+// from the current time.
+func WithClock(c Clock) Option {
+	return func(l *logwriter) {
+		l.clock = c
+	}
+}
+
+// processStart approximates the start of the process with the initialization of the package.
+var processStart = time.Now()
+
+// Uptime stamps every entry with the milliseconds since the process started
+// in the upms field. The uptime is measured with the monotonic clock, so it is
+// not affected by jumps of the wall clock and orders the entries of a process
+// even if their time stamps do not. If a clock is set by WithClock, the uptime
+// is measured with that clock from the first entry instead.
+func Uptime() Option {
+	return func(l *logwriter) {
+		var start time.Time
+		l.before = append(l.before, func(e *Entry) bool {
+			var d time.Duration
+			if l.clock == nil {
+				d = time.Since(processStart)
+			} else if now := l.clock.Now(); start.IsZero() {
+				start = now
+			} else {
+				d = now.Sub(start)
+			}
+			e.Fields = append(e.Fields, Field{"upms", IntValue(int64(d / time.Millisecond))})
+			return true
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package slog
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+func TestWithClock(t *testing.T) {
+	clock := fixedClock(time.Date(2021, 2, 3, 4, 5, 6, 7000, time.UTC))
+
+	var b bytes.Buffer
+	l := New(&b, "", log.LstdFlags|log.Lmicroseconds|log.LUTC|Lmessage, WithClock(clock), EntryID())
+	l.Print("hello")
+
+	if want := `{"time":"2021-02-03T04:05:06.000007Z","mesg":"hello","id":"01776610-b150-7`; !strings.HasPrefix(b.String(), want) {
+		t.Fatal(b.String())
+	}
+}
+
+func TestMiddlewareClock(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields)
+	clock := fixedClock(time.Unix(0, 0))
+	h := Middleware(l, MiddlewareOptions{Clock: clock})(http.NotFoundHandler())
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+	if !strings.Contains(b.String(), `"durms":0`) {
+		t.Fatal(b.String())
+	}
+}
+
+func TestUptime(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields, Uptime())
+
+	processStart = processStart.Add(-1500 * time.Millisecond)
+	l.Print("a=1")
+
+	var fields struct{ A, Upms int }
+	if err := json.Unmarshal(b.Bytes(), &fields); err != nil || fields.A != 1 || fields.Upms < 1500 {
+		t.Fatal(b.String(), err)
+	}
+}
+
+func TestUptimeClock(t *testing.T) {
+	var b bytes.Buffer
+	clock := &stepClock{time.Unix(0, 0), 1500 * time.Millisecond}
+	l := New(&b, "", 0, Uptime(), WithClock(clock))
+	l.Print()
+	l.Print()
+
+	if exp := "{\"upms\":0}\n{\"upms\":1500}\n"; b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
@@ -0,0 +1,201 @@
+// Command slog converts and inspects structured logs.
+//
+// Usage:
+//
+//	slog verify [-key key] [-chain] [-crc] [file ...]
+//	slog stats [-keys keys] [-durations fields] [-interval interval] [-top n] [file ...]
+//	slog merge [file ...]
+//	slog text [-prefix prefix] [-flags flags] [file ...]
+//	slog replay [-speed factor] [-o sink] [-async capacity] [-sync n] [-cacert file] [-cert file -key file] [file ...]
+//
+// Verify checks the signatures of entries produced by a writer configured with SignHMAC
+// if -key is given, the hash chain produced by a writer configured with HashChain if -chain is given,
+// and the checksums produced by a writer configured with Checksum if -crc is given.
+// Stats reports the number of entries by level, prefix, message and the values of the fields
+// listed by -keys, the number of entries per interval and the percentiles of the duration fields.
+// Merge interleaves the entries of several structured logs in time stamp order.
+// Replay writes recorded entries to a sink at their original pace, accelerated by -speed,
+// and reports the throughput, to benchmark sinks and asynchronous writers with realistic data.
+// Sinks of the form tls://host:port are written over TLS, with client certificates if -cert and -key are given.
+// Flags are given as names such as date|time|utc or as a number.
+// Files with the .gz extension are decompressed.
+// Files are read from stdin if none are given.
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/askeladdk/slog"
+)
+
+// stdout is where the commands write their output.
+var stdout io.Writer = os.Stdout
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{"verify", "[-key key] [-chain] [-crc] [file ...]", verify},
+	{"stats", "[-keys keys] [-durations fields] [-interval interval] [-top n] [file ...]", statsCmd},
+	{"merge", "[file ...]", merge},
+	{"text", "[-prefix prefix] [-flags flags] [file ...]", text},
+	{"replay", "[-speed factor] [-o sink] [-async capacity] [-sync n] [-cacert file] [-cert file -key file] [file ...]", replay},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "\tslog %s %s\n", cmd.name, cmd.usage)
+	}
+	os.Exit(2)
+}
+
+// inputs opens the named files or returns stdin if there are none.
+// Files with the .gz extension are decompressed.
+func inputs(names []string) ([]io.Reader, func(), error) {
+	if len(names) == 0 {
+		return []io.Reader{os.Stdin}, func() {}, nil
+	}
+
+	var files []*os.File
+	closeAll := func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}
+
+	readers := make([]io.Reader, 0, len(names))
+	for _, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+		files = append(files, f)
+		if !strings.HasSuffix(name, ".gz") {
+			readers = append(readers, f)
+			continue
+		}
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+		readers = append(readers, zr)
+	}
+
+	return readers, closeAll, nil
+}
+
+// flagsValue is a flag.Value of logger flags given by name.
+type flagsValue int
+
+func (v *flagsValue) String() string { return slog.FormatFlags(int(*v)) }
+
+func (v *flagsValue) Set(s string) error {
+	flags, err := slog.ParseFlags(s)
+	*v = flagsValue(flags)
+	return err
+}
+
+func flagsVar(fs *flag.FlagSet, value int) *int {
+	v := flagsValue(value)
+	fs.Var(&v, "flags", "logger flags, such as date|time|utc")
+	return (*int)(&v)
+}
+
+func verify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	key := fs.String("key", "", "HMAC key")
+	chain := fs.Bool("chain", false, "verify the hash chain")
+	crc := fs.Bool("crc", false, "verify the checksums")
+	_ = fs.Parse(args)
+
+	if *key == "" && !*chain && !*crc {
+		return errors.New("verify: missing -key, -chain or -crc")
+	}
+
+	readers, closeAll, err := inputs(fs.Args())
+	if err != nil {
+		return err
+	}
+	defer closeAll()
+
+	var failed int
+	for i, r := range readers {
+		name := "stdin"
+		if fs.NArg() > 0 {
+			name = fs.Arg(i)
+		}
+
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+
+		if *key != "" {
+			for i, line := range bytes.Split(data, []byte{'\n'}) {
+				// anchors of the hash chain are not signed
+				if len(line) == 0 || bytes.HasPrefix(line, []byte(`{"anchr":`)) {
+					continue
+				}
+				if err := slog.VerifyHMAC(line, []byte(*key)); err != nil {
+					fmt.Printf("%s:%d: %v\n", name, i+1, err)
+					failed++
+				}
+			}
+		}
+
+		if *crc {
+			for i, line := range bytes.Split(data, []byte{'\n'}) {
+				if len(line) == 0 {
+					continue
+				}
+				if err := slog.VerifyChecksum(line); err != nil {
+					fmt.Printf("%s:%d: %v\n", name, i+1, err)
+					failed++
+				}
+			}
+		}
+
+		if *chain {
+			if n, err := slog.VerifyChain(bytes.NewReader(data)); err != nil {
+				fmt.Printf("%s: %v (after %d entries)\n", name, err, n)
+				failed++
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d verification errors", failed)
+	}
+	return nil
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == os.Args[1] {
+			if err := cmd.run(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, "slog:", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	usage()
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"flag"
+	"io"
+	"time"
+
+	"github.com/askeladdk/slog"
+)
+
+// stream is an input of the merge with its next line.
+type stream struct {
+	r    *bufio.Reader
+	line []byte
+	time time.Time
+	idx  int
+}
+
+// next reads the next non-empty line. Lines without a time stamp
+// keep the time stamp of the previous line, so that they stay in place.
+func (s *stream) next() (bool, error) {
+	for {
+		line, err := s.r.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			if line[len(line)-1] != '\n' {
+				line = append(line, '\n')
+			}
+			s.line = line
+
+			var e slog.Entry
+			if slog.NewDecoder(bytes.NewReader(line)).Decode(&e) == nil && !e.Time.IsZero() {
+				s.time = e.Time
+			}
+			return true, nil
+		}
+		if err == io.EOF {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+	}
+}
+
+// streams is a min heap of streams ordered by time stamp and input order.
+type streams []*stream
+
+func (h streams) Len() int { return len(h) }
+func (h streams) Less(i, j int) bool {
+	if !h[i].time.Equal(h[j].time) {
+		return h[i].time.Before(h[j].time)
+	}
+	return h[i].idx < h[j].idx
+}
+func (h streams) Swap(i, j int)         { h[i], h[j] = h[j], h[i] }
+func (h *streams) Push(x interface{})   { *h = append(*h, x.(*stream)) }
+func (h *streams) Pop() (x interface{}) { x, *h = (*h)[len(*h)-1], (*h)[:len(*h)-1]; return }
+func (h streams) peek() *stream         { return h[0] }
+
+func merge(args []string) error {
+	fs := flag.NewFlagSet("merge", flag.ExitOnError)
+	_ = fs.Parse(args)
+
+	readers, closeAll, err := inputs(fs.Args())
+	if err != nil {
+		return err
+	}
+	defer closeAll()
+
+	var h streams
+	for i, r := range readers {
+		s := &stream{r: bufio.NewReader(r), idx: i}
+		if ok, err := s.next(); err != nil {
+			return err
+		} else if ok {
+			h = append(h, s)
+		}
+	}
+	heap.Init(&h)
+
+	w := bufio.NewWriter(stdout)
+	defer w.Flush()
+
+	for h.Len() > 0 {
+		s := h.peek()
+		if _, err := w.Write(s.line); err != nil {
+			return err
+		}
+		if ok, err := s.next(); err != nil {
+			return err
+		} else if ok {
+			heap.Fix(&h, 0)
+		} else {
+			heap.Pop(&h)
+		}
+	}
+
+	return nil
+}
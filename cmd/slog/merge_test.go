@@ -0,0 +1,65 @@
+package main
+
+import "testing"
+
+func TestMerge(t *testing.T) {
+	a := writeFile(t, "a.log",
+		`{"time":"2021-08-08T19:06:01Z","mesg":"a1"}`,
+		`{"time":"2021-08-08T19:06:03Z","mesg":"a2"}`,
+		`{"time":"2021-08-08T19:06:05Z","mesg":"a3"}`,
+	)
+	b := writeFile(t, "b.log",
+		`{"time":"2021-08-08T19:06:02Z","mesg":"b1"}`,
+		`{"time":"2021-08-08T19:06:03Z","mesg":"b2"}`,
+		`{"time":"2021-08-08T19:06:04Z","mesg":"b3"}`,
+	)
+	c := writeFile(t, "c.log",
+		`{"time":"2021-08-08T19:06:02Z","mesg":"c1"}`,
+		`not json`,
+		`{"mesg":"c2"}`,
+		``,
+		`{"time":"2021-08-08T19:06:06Z","mesg":"c3"}`,
+	)
+
+	for _, testCase := range []struct {
+		Args []string
+		Exp  string
+	}{
+		{
+			// interleaved time stamps
+			[]string{a, b},
+			`{"time":"2021-08-08T19:06:01Z","mesg":"a1"}` + "\n" +
+				`{"time":"2021-08-08T19:06:02Z","mesg":"b1"}` + "\n" +
+				`{"time":"2021-08-08T19:06:03Z","mesg":"a2"}` + "\n" +
+				`{"time":"2021-08-08T19:06:03Z","mesg":"b2"}` + "\n" +
+				`{"time":"2021-08-08T19:06:04Z","mesg":"b3"}` + "\n" +
+				`{"time":"2021-08-08T19:06:05Z","mesg":"a3"}` + "\n",
+		},
+		{
+			// equal time stamps are written in the order of the inputs
+			[]string{b, a},
+			`{"time":"2021-08-08T19:06:01Z","mesg":"a1"}` + "\n" +
+				`{"time":"2021-08-08T19:06:02Z","mesg":"b1"}` + "\n" +
+				`{"time":"2021-08-08T19:06:03Z","mesg":"b2"}` + "\n" +
+				`{"time":"2021-08-08T19:06:03Z","mesg":"a2"}` + "\n" +
+				`{"time":"2021-08-08T19:06:04Z","mesg":"b3"}` + "\n" +
+				`{"time":"2021-08-08T19:06:05Z","mesg":"a3"}` + "\n",
+		},
+		{
+			// lines without a time stamp stay after the previous line
+			// and empty lines are skipped
+			[]string{c, b},
+			`{"time":"2021-08-08T19:06:02Z","mesg":"c1"}` + "\n" +
+				`not json` + "\n" +
+				`{"mesg":"c2"}` + "\n" +
+				`{"time":"2021-08-08T19:06:02Z","mesg":"b1"}` + "\n" +
+				`{"time":"2021-08-08T19:06:03Z","mesg":"b2"}` + "\n" +
+				`{"time":"2021-08-08T19:06:04Z","mesg":"b3"}` + "\n" +
+				`{"time":"2021-08-08T19:06:06Z","mesg":"c3"}` + "\n",
+		},
+	} {
+		if out := run(t, merge, testCase.Args...); out != testCase.Exp {
+			t.Fatalf("%v:\n%s", testCase.Args, out)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/askeladdk/slog"
+)
+
+// tlsConfig loads the CA certificates used to verify the server and the
+// client certificate for mutual TLS, which are optional.
+func tlsConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	config := &tls.Config{}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%s: no certificates", caFile)
+		}
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// openSink opens the output of the replay. The sink is - for stdout, discard,
+// a network address such as tcp://host:port, tls://host:port, udp://host:port
+// or unix:///path, or the name of a file.
+func openSink(sink string, syncEvery int, config *tls.Config) (io.Writer, func() error, error) {
+	switch {
+	case sink == "-":
+		return os.Stdout, func() error { return nil }, nil
+	case sink == "discard":
+		return io.Discard, func() error { return nil }, nil
+	case strings.Contains(sink, "://"):
+		i := strings.Index(sink, "://")
+		network, opts := sink[:i], slog.NetOptions{}
+		if network == "tls" {
+			network, opts.TLS = "tcp", config
+		}
+		nw, err := slog.Dial(network, sink[i+3:], opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nw, nw.Close, nil
+	}
+	fw, err := slog.OpenFile(sink, slog.FileOptions{SyncEvery: syncEvery})
+	if err != nil {
+		return nil, nil, err
+	}
+	return fw, fw.Close, nil
+}
+
+func replay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	speed := fs.Float64("speed", 1, "pace relative to the original, 0 to write as fast as possible")
+	sink := fs.String("o", "-", "sink: -, discard, tcp://addr, tls://addr, udp://addr, unix://path or a file name")
+	async := fs.Int("async", 0, "capacity of an asynchronous writer in front of the sink, 0 to write synchronously")
+	syncEvery := fs.Int("sync", 0, "sync a file sink after every n entries")
+	caFile := fs.String("cacert", "", "CA certificates of a tls sink in PEM format")
+	certFile := fs.String("cert", "", "client certificate of a tls sink in PEM format")
+	keyFile := fs.String("key", "", "client key of a tls sink in PEM format")
+	_ = fs.Parse(args)
+
+	config, err := tlsConfig(*caFile, *certFile, *keyFile)
+	if err != nil {
+		return err
+	}
+
+	readers, closeAll, err := inputs(fs.Args())
+	if err != nil {
+		return err
+	}
+	defer closeAll()
+
+	w, closeSink, err := openSink(*sink, *syncEvery, config)
+	if err != nil {
+		return err
+	}
+	defer closeSink()
+
+	var aw *slog.AsyncWriter
+	if *async > 0 {
+		aw = slog.NewAsyncWriter(w, slog.AsyncOptions{Capacity: *async})
+		w = aw
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var total slog.ReplayStats
+	for _, r := range readers {
+		stats, err := slog.Replay(ctx, w, r, slog.ReplayOptions{Speed: *speed})
+		total.Entries += stats.Entries
+		total.Bytes += stats.Bytes
+		total.Elapsed += stats.Elapsed
+		if err != nil {
+			return err
+		}
+	}
+
+	if aw != nil {
+		start := time.Now()
+		if err := aw.Drain(ctx); err != nil {
+			return err
+		}
+		total.Elapsed += time.Since(start)
+		if s := aw.Stats(); s.Dropped > 0 {
+			fmt.Fprintf(os.Stderr, "dropped: %d\n", s.Dropped)
+		}
+	}
+
+	secs := total.Elapsed.Seconds()
+	fmt.Fprintf(os.Stderr, "entries: %d\nbytes: %d\nelapsed: %s\nrate: %.0f entries/s, %.2f MB/s\n",
+		total.Entries, total.Bytes, total.Elapsed, float64(total.Entries)/secs, float64(total.Bytes)/secs/1e6)
+	return nil
+}
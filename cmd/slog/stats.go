@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/askeladdk/slog"
+)
+
+// counter counts occurrences of strings.
+type counter map[string]int
+
+type count struct {
+	key string
+	n   int
+}
+
+// top returns the n most frequent strings, or all if n is not positive.
+func (c counter) top(n int) []count {
+	counts := make([]count, 0, len(c))
+	for key, n := range c {
+		counts = append(counts, count{key, n})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].n != counts[j].n {
+			return counts[i].n > counts[j].n
+		}
+		return counts[i].key < counts[j].key
+	})
+	if n > 0 && len(counts) > n {
+		counts = counts[:n]
+	}
+	return counts
+}
+
+type stats struct {
+	total     int
+	levels    counter
+	prefixes  counter
+	messages  counter
+	keys      map[string]counter
+	durations map[string][]float64
+	rates     map[time.Time]int
+	keyNames  []string
+	durNames  []string
+}
+
+func newStats(keys, durations []string) *stats {
+	s := &stats{
+		levels:    counter{},
+		prefixes:  counter{},
+		messages:  counter{},
+		keys:      map[string]counter{},
+		durations: map[string][]float64{},
+		rates:     map[time.Time]int{},
+		keyNames:  keys,
+		durNames:  durations,
+	}
+	for _, key := range keys {
+		s.keys[key] = counter{}
+	}
+	for _, key := range durations {
+		s.durations[key] = nil
+	}
+	return s
+}
+
+func (s *stats) add(e *slog.Entry, interval time.Duration) {
+	s.total++
+
+	level := "none"
+	if v, ok := e.Get("levl"); ok {
+		level = v.String()
+	} else if v, ok := e.Get("level"); ok {
+		level = v.String()
+	}
+	s.levels[level]++
+
+	if e.Prefix != "" {
+		s.prefixes[e.Prefix]++
+	}
+	if e.Message != "" {
+		s.messages[e.Message]++
+	}
+	if !e.Time.IsZero() {
+		s.rates[e.Time.Truncate(interval)]++
+	}
+
+	for key, c := range s.keys {
+		if v, ok := e.Get(key); ok {
+			c[v.String()]++
+		}
+	}
+	for key, ds := range s.durations {
+		if v, ok := e.Get(key); ok && (v.Kind() == slog.KindInt || v.Kind() == slog.KindFloat) {
+			s.durations[key] = append(ds, v.Float())
+		}
+	}
+}
+
+// percentile returns the nearest-rank percentile of sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	i := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
+
+func (s *stats) print(w io.Writer, top int) {
+	fmt.Fprintf(w, "entries: %d\n", s.total)
+
+	printCounts := func(title string, c counter) {
+		if len(c) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "\n%s:\n", title)
+		for _, c := range c.top(top) {
+			fmt.Fprintf(w, "\t%8d\t%s\n", c.n, c.key)
+		}
+	}
+
+	printCounts("levels", s.levels)
+	printCounts("prefixes", s.prefixes)
+	for _, key := range s.keyNames {
+		printCounts(key, s.keys[key])
+	}
+	printCounts("messages", s.messages)
+
+	if len(s.rates) > 0 {
+		times := make([]time.Time, 0, len(s.rates))
+		for t := range s.rates {
+			times = append(times, t)
+		}
+		sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+		fmt.Fprintf(w, "\nrate:\n")
+		for _, t := range times {
+			fmt.Fprintf(w, "\t%s\t%d\n", t.Format(time.RFC3339), s.rates[t])
+		}
+	}
+
+	for _, key := range s.durNames {
+		ds := s.durations[key]
+		if len(ds) == 0 {
+			continue
+		}
+		sort.Float64s(ds)
+		fmt.Fprintf(w, "\n%s:\n\tcount=%d p50=%g p90=%g p99=%g max=%g\n", key, len(ds),
+			percentile(ds, 50), percentile(ds, 90), percentile(ds, 99), ds[len(ds)-1])
+	}
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func statsCmd(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	keys := fs.String("keys", "", "comma separated fields whose values are counted")
+	durations := fs.String("durations", "durms", "comma separated duration fields")
+	interval := fs.Duration("interval", time.Minute, "interval of the rate histogram")
+	top := fs.Int("top", 10, "number of most frequent values shown")
+	_ = fs.Parse(args)
+
+	readers, closeAll, err := inputs(fs.Args())
+	if err != nil {
+		return err
+	}
+	defer closeAll()
+
+	s := newStats(splitList(*keys), splitList(*durations))
+	for _, r := range readers {
+		d := slog.NewDecoder(r)
+		for {
+			var e slog.Entry
+			if err := d.Decode(&e); err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+			s.add(&e, *interval)
+		}
+	}
+
+	w := bufio.NewWriter(stdout)
+	defer w.Flush()
+	s.print(w, *top)
+	return nil
+}
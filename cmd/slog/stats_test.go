@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// run runs cmd with args and returns its output.
+func run(t *testing.T, cmd func([]string) error, args ...string) string {
+	var b bytes.Buffer
+	stdout = &b
+	defer func() { stdout = os.Stdout }()
+	if err := cmd(args); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+// writeFile writes the lines to a file in a temporary directory and returns its name.
+func writeFile(t *testing.T, name string, lines ...string) string {
+	name = filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(name, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func TestCounterTop(t *testing.T) {
+	c := counter{"a": 1, "b": 3, "c": 3, "d": 2}
+	for _, testCase := range []struct {
+		N   int
+		Exp []count
+	}{
+		{0, []count{{"b", 3}, {"c", 3}, {"d", 2}, {"a", 1}}},
+		{2, []count{{"b", 3}, {"c", 3}}},
+		{10, []count{{"b", 3}, {"c", 3}, {"d", 2}, {"a", 1}}},
+	} {
+		if top := c.top(testCase.N); !reflect.DeepEqual(top, testCase.Exp) {
+			t.Fatal(testCase.N, top)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	for _, testCase := range []struct {
+		P   float64
+		Exp float64
+	}{
+		{0, 1},
+		{10, 1},
+		{50, 5},
+		{90, 9},
+		{99, 10},
+		{100, 10},
+	} {
+		if p := percentile(sorted, testCase.P); p != testCase.Exp {
+			t.Fatal(testCase.P, p)
+		}
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	for _, testCase := range []struct {
+		Str string
+		Exp []string
+	}{
+		{"", nil},
+		{"a", []string{"a"}},
+		{"a,b", []string{"a", "b"}},
+	} {
+		if l := splitList(testCase.Str); !reflect.DeepEqual(l, testCase.Exp) {
+			t.Fatal(testCase.Str, l)
+		}
+	}
+}
+
+func TestStats(t *testing.T) {
+	name := writeFile(t, "app.log",
+		`{"time":"2021-08-08T19:06:10Z","levl":"info","prfx":"api","mesg":"request","code":200,"durms":10}`,
+		`{"time":"2021-08-08T19:06:50Z","levl":"error","prfx":"api","mesg":"request","code":500,"durms":30}`,
+		`{"time":"2021-08-08T19:07:05Z","level":"info","prfx":"db","mesg":"query","durms":20}`,
+		`{"mesg":"started"}`,
+	)
+
+	for _, testCase := range []struct {
+		Args []string
+		Exp  []string
+	}{
+		{
+			[]string{name},
+			[]string{
+				"entries: 4\n",
+				"levels:\n\t       2\tinfo\n\t       1\terror\n\t       1\tnone\n",
+				"prefixes:\n\t       2\tapi\n\t       1\tdb\n",
+				"messages:\n\t       2\trequest\n\t       1\tquery\n\t       1\tstarted\n",
+				"rate:\n\t2021-08-08T19:06:00Z\t2\n\t2021-08-08T19:07:00Z\t1\n",
+				"durms:\n\tcount=3 p50=20 p90=30 p99=30 max=30\n",
+			},
+		},
+		{
+			[]string{"-keys", "code", "-top", "1", name},
+			[]string{
+				"levels:\n\t       2\tinfo\n\n",
+				"code:\n\t       1\t200\n\n",
+				"messages:\n\t       2\trequest\n\n",
+			},
+		},
+		{
+			[]string{"-interval", "1h", "-durations", "", name},
+			[]string{"rate:\n\t2021-08-08T19:00:00Z\t3\n"},
+		},
+	} {
+		out := run(t, statsCmd, testCase.Args...)
+		for _, exp := range testCase.Exp {
+			if !strings.Contains(out, exp) {
+				t.Fatalf("%v: missing %q in\n%s", testCase.Args, exp, out)
+			}
+		}
+	}
+
+	if out := run(t, statsCmd, "-durations", "", name); strings.Contains(out, "durms") {
+		t.Fatal(out)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/askeladdk/slog"
+)
+
+// appendText formats an entry like a standard logger with the prefix and flags.
+// The prefix of the entry is used if prefix is empty. The fields are formatted
+// as key=value pairs if the entry has no message, quoted like slog.KV so that
+// they are parsed back as the same fields.
+func appendText(dst []byte, e *slog.Entry, prefix string, flags int) []byte {
+	if prefix == "" && e.Prefix != "" {
+		prefix = strings.Join(e.PrefixPath(), ": ") + ": "
+	}
+	if flags&log.Lmsgprefix == 0 {
+		dst = append(dst, prefix...)
+	}
+
+	if t := e.Time; !t.IsZero() {
+		if flags&log.LUTC != 0 {
+			t = t.UTC()
+		}
+		if flags&log.Ldate != 0 {
+			dst = t.AppendFormat(dst, "2006/01/02 ")
+		}
+		if flags&(log.Ltime|log.Lmicroseconds) != 0 {
+			dst = t.AppendFormat(dst, "15:04:05")
+			if flags&log.Lmicroseconds != 0 {
+				dst = t.AppendFormat(dst, ".000000")
+			}
+			dst = append(dst, ' ')
+		}
+	}
+
+	if flags&(log.Lshortfile|log.Llongfile) != 0 && e.File != "" {
+		file := e.File
+		if flags&log.Lshortfile != 0 {
+			file = file[strings.LastIndexAny(file, `/\`)+1:]
+		}
+		dst = append(dst, file...)
+		dst = append(dst, ':')
+		dst = strconv.AppendInt(dst, int64(e.Line), 10)
+		dst = append(dst, ": "...)
+	}
+
+	if flags&log.Lmsgprefix != 0 {
+		dst = append(dst, prefix...)
+	}
+
+	if e.Message != "" {
+		dst = append(dst, e.Message...)
+	} else {
+		for i, f := range e.Fields {
+			if i > 0 {
+				dst = append(dst, ' ')
+			}
+			dst = append(dst, slog.KV(f.Key, f.Value)...)
+		}
+	}
+
+	return append(dst, '\n')
+}
+
+func text(args []string) error {
+	fs := flag.NewFlagSet("text", flag.ExitOnError)
+	prefix := fs.String("prefix", "", "logger prefix, derived from the prfx field if empty")
+	flags := flagsVar(fs, log.LstdFlags)
+	_ = fs.Parse(args)
+
+	readers, closeAll, err := inputs(fs.Args())
+	if err != nil {
+		return err
+	}
+	defer closeAll()
+
+	w := bufio.NewWriter(stdout)
+	defer w.Flush()
+
+	var buf []byte
+	for _, r := range readers {
+		d := slog.NewDecoder(r)
+		for {
+			var e slog.Entry
+			if err := d.Decode(&e); err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+			buf = appendText(buf[:0], &e, *prefix, *flags)
+			if _, err := w.Write(buf); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"log"
+	"testing"
+	"time"
+
+	"github.com/askeladdk/slog"
+)
+
+func TestAppendText(t *testing.T) {
+	e := slog.Entry{
+		Time:    time.Date(2021, 8, 8, 19, 6, 35, 252044000, time.UTC),
+		Prefix:  "api.db",
+		File:    "/src/app/main.go",
+		Line:    42,
+		Message: "hello",
+	}
+
+	for _, testCase := range []struct {
+		Prefix string
+		Flags  int
+		Exp    string
+	}{
+		{"", log.LstdFlags | log.LUTC, "api: db: 2021/08/08 19:06:35 hello\n"},
+		{"", log.Ltime | log.Lmicroseconds | log.LUTC, "api: db: 19:06:35.252044 hello\n"},
+		{"app: ", log.Ldate | log.Lshortfile | log.Lmsgprefix | log.LUTC, "2021/08/08 main.go:42: app: hello\n"},
+		{"", log.Llongfile, "api: db: /src/app/main.go:42: hello\n"},
+	} {
+		if s := string(appendText(nil, &e, testCase.Prefix, testCase.Flags)); s != testCase.Exp {
+			t.Fatalf("%q", s)
+		}
+	}
+}
+
+func TestAppendTextFields(t *testing.T) {
+	e := slog.Entry{
+		Fields: []slog.Field{
+			{Key: "a", Value: slog.StringValue(`say "hi"`)},
+			{Key: "b", Value: slog.StringValue("")},
+			{Key: "c", Value: slog.StringValue("x=y")},
+			{Key: "d", Value: slog.StringValue("007")},
+			{Key: "e", Value: slog.IntValue(7)},
+			{Key: "f", Value: slog.BoolValue(true)},
+		},
+	}
+
+	line := string(appendText(nil, &e, "", 0))
+	if exp := `a="say 'hi'" b="" c="x=y" d=007 e=7 f=true` + "\n"; line != exp {
+		t.Fatalf("%q", line)
+	}
+
+	p, err := slog.Parse(line, "", slog.Lparsefields)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(p.Fields) != len(e.Fields) {
+		t.Fatal(p.Fields)
+	}
+	for i, f := range p.Fields {
+		exp := e.Fields[i]
+		if exp.Key == "a" {
+			exp.Value = slog.StringValue("say 'hi'")
+		}
+		if f.Key != exp.Key || f.Value.Kind() != exp.Value.Kind() || f.Value.String() != exp.Value.String() {
+			t.Fatal(f.Key, f.Value)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	name := writeFile(t, "app.log",
+		`{"time":"2021-08-08T19:06:35Z","prfx":"app","mesg":"hello"}`,
+		`{"code":200,"path":"/a b"}`,
+	)
+	exp := "app: 2021/08/08 19:06:35 hello\ncode=200 path=\"/a b\"\n"
+	if out := run(t, text, "-flags", "date|time|utc", name); out != exp {
+		t.Fatalf("%q", out)
+	}
+}
@@ -0,0 +1,102 @@
+package slog
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ComponentLevels holds the minimum levels of components, which are identified
+// by the prefix of the logger with its components joined by dots, as in the prfx field.
+// The level of a component applies to its subcomponents, such that the level of
+// "db" applies to "db.pool" unless that has its own level. Components without
+// a level use the Default level. It is safe for concurrent use and can be
+// changed while the program runs. The zero value uses the info level for all components.
+type ComponentLevels struct {
+	// Default is the level of the components without a level.
+	Default LevelVar
+
+	mu     sync.RWMutex
+	levels map[string]Level
+}
+
+// Set sets the level of a component.
+func (c *ComponentLevels) Set(component string, level Level) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.levels == nil {
+		c.levels = map[string]Level{}
+	}
+	c.levels[component] = level
+}
+
+// Delete removes the level of a component.
+func (c *ComponentLevels) Delete(component string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.levels, component)
+}
+
+// Levels returns the levels of all components that have one.
+func (c *ComponentLevels) Levels() map[string]Level {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	levels := make(map[string]Level, len(c.levels))
+	for component, level := range c.levels {
+		levels[component] = level
+	}
+	return levels
+}
+
+// Parse replaces the levels of all components by the level names in
+// the map, such as {"db": "debug", "http": "warn"}. Nothing is changed
+// if a level name is not recognized.
+func (c *ComponentLevels) Parse(names map[string]string) error {
+	levels := make(map[string]Level, len(names))
+	for component, name := range names {
+		level, ok := ParseLevel(name)
+		if !ok {
+			return errors.New("slog: unknown level " + name + " of component " + component)
+		}
+		levels[component] = level
+	}
+	c.mu.Lock()
+	c.levels = levels
+	c.mu.Unlock()
+	return nil
+}
+
+// Level returns the level of a component.
+func (c *ComponentLevels) Level(component string) Level {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for len(c.levels) > 0 {
+		if level, ok := c.levels[component]; ok {
+			return level
+		}
+		i := strings.LastIndexByte(component, '.')
+		if i == -1 {
+			break
+		}
+		component = component[:i]
+	}
+	return c.Default.Level()
+}
+
+// MinLevelByComponent drops the entries below the level of their component.
+// Entries without a level are treated as info. The component of an entry
+// is its prefix, which is not known if log.Lmsgprefix is set.
+//
+//	var levels slog.ComponentLevels
+//	levels.Set("db", slog.LevelDebug)
+//	l := slog.New(os.Stderr, "", slog.LstdFlags, slog.MinLevelByComponent(&levels))
+//	db := slog.Named(l, "db")
+func MinLevelByComponent(c *ComponentLevels) Option {
+	return BeforeWrite(func(e *Entry) bool {
+		level, ok := entryLevel(e, e.Message)
+		if !ok {
+			level = LevelInfo
+		}
+		return level >= c.Level(e.Prefix)
+	})
+}
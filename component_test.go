@@ -0,0 +1,38 @@
+package slog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMinLevelByComponent(t *testing.T) {
+	var levels ComponentLevels
+	levels.Default.Set(LevelWarn)
+	if err := levels.Parse(map[string]string{"db": "debug", "db.pool": "error"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields, MinLevelByComponent(&levels))
+	db := Named(l, "db")
+	l.Print("levl=info a=1")
+	db.Print("levl=debug a=2")
+	Named(db, "query").Print("levl=debug a=3")
+	Named(db, "pool").Print("levl=warn a=4")
+	l.Print("levl=warn a=5")
+
+	want := `{"prfx":"db","levl":"debug","a":2}` + "\n" +
+		`{"prfx":"db.query","levl":"debug","a":3}` + "\n" +
+		`{"levl":"warn","a":5}` + "\n"
+	if b.String() != want {
+		t.Fatal(b.String())
+	}
+
+	if err := levels.Parse(map[string]string{"db": "loud"}); err == nil || levels.Level("db") != LevelDebug {
+		t.Fatal(err)
+	}
+	levels.Delete("db")
+	if levels.Level("db.query") != LevelWarn {
+		t.Fatal(levels.Level("db.query"))
+	}
+}
@@ -0,0 +1,298 @@
+package slog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Config is the logging configuration that is read from a file by ConfigWriter.
+// The file contains a JSON object, for example:
+//
+//	{
+//		"format": "logstash",
+//		"level": "debug",
+//		"sinks": {"stderr": true, "slack": false},
+//		"redact": ["password", "token"],
+//		"names": {"prefix": "component"}
+//	}
+//
+// YAML files must be converted to JSON.
+type Config struct {
+	// Format is the output format, json or logstash. Defaults to json.
+	Format string `json:"format,omitempty"`
+
+	// Level is the minimum level of the entries that are written. Defaults to info.
+	Level string `json:"level,omitempty"`
+
+	// Sinks turns the named outputs on and off. Sinks that are not listed are on.
+	Sinks map[string]bool `json:"sinks,omitempty"`
+
+	// Redact lists the keys of the fields whose values are replaced by [REDACTED].
+	Redact []string `json:"redact,omitempty"`
+
+	// Names renames the fields that slog extracts from the log line.
+	// The keys are prefix, time, file, line, func, message, raw and text.
+	Names map[string]string `json:"names,omitempty"`
+}
+
+// ParseConfig parses and validates a configuration.
+func ParseConfig(data []byte) (Config, error) {
+	var c Config
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	if err := d.Decode(&c); err != nil {
+		return Config{}, fmt.Errorf("slog: config: %w", err)
+	}
+	switch c.Format {
+	case "", "json", "logstash":
+	default:
+		return Config{}, fmt.Errorf("slog: config: unknown format %s", c.Format)
+	}
+	if c.Level != "" {
+		if _, ok := ParseLevel(c.Level); !ok {
+			return Config{}, fmt.Errorf("slog: config: unknown level %s", c.Level)
+		}
+	}
+	var names fieldNames
+	if err := c.applyNames(&names); err != nil {
+		return Config{}, err
+	}
+	return c, nil
+}
+
+func (c *Config) applyNames(names *fieldNames) error {
+	for key, name := range c.Names {
+		var p *string
+		switch key {
+		case "prefix":
+			p = &names.prefix
+		case "time":
+			p = &names.time
+		case "file":
+			p = &names.file
+		case "line":
+			p = &names.line
+		case "func":
+			p = &names.function
+		case "message":
+			p = &names.message
+		case "raw":
+			p = &names.raw
+		case "text":
+			p = &names.text
+		default:
+			return fmt.Errorf("slog: config: unknown field name %s", key)
+		}
+		if name == "" {
+			return fmt.Errorf("slog: config: empty field name for %s", key)
+		}
+		*p = name
+	}
+	return nil
+}
+
+// options returns the options that implement the configuration.
+func (c *Config) options(level *LevelVar) []Option {
+	var opts []Option
+	if c.Format == "logstash" {
+		opts = append(opts, Logstash())
+	}
+	if len(c.Names) > 0 {
+		opts = append(opts, func(l *logwriter) {
+			_ = c.applyNames(&l.names)
+		})
+	}
+	if len(c.Redact) > 0 {
+		redact := func(Value) Value { return StringValue("[REDACTED]") }
+		transformers := make(map[string]Transformer, len(c.Redact))
+		for _, key := range c.Redact {
+			transformers[key] = redact
+		}
+		opts = append(opts, Transform(transformers))
+	}
+	return append(opts, MinLevel(level))
+}
+
+// fanout writes to all of its writers.
+type fanout []io.Writer
+
+func (f fanout) Write(p []byte) (int, error) {
+	var err error
+	for _, w := range f {
+		if _, werr := w.Write(p); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return len(p), err
+}
+
+func (f fanout) Unwrap() []io.Writer { return f }
+
+// ConfigWriter is a structured writer whose format, level, sinks, redacted
+// fields and field names are read from a configuration file.
+// Reloading the file replaces the configuration atomically, such that
+// every entry is encoded with either the old or the new configuration.
+// Writes are serialized across reloads. The options are applied again at every
+// reload, so the options that keep state between entries, such as HashChain,
+// RateLimit and LimitCardinality, start over: a hash chain restarts at seqn 1.
+type ConfigWriter struct {
+	path   string
+	prefix string
+	flags  int
+	opts   []Option
+	out    fanout
+	sinks  map[string]*Switch
+	cur    atomic.Value // *logwriter
+	config atomic.Value // Config
+	wmu    sync.Mutex   // serializes writes of all configurations
+
+	mu      sync.Mutex // serializes reloads
+	modTime time.Time
+	size    int64
+}
+
+// NewConfigWriter creates a structured writer that writes to the named sinks
+// and is configured by the file at path. The options are applied before the
+// configuration. The prefix and flags of the logger must not be changed afterwards.
+// It returns an error if the file cannot be read or is invalid.
+func NewConfigWriter(path string, l *log.Logger, sinks map[string]io.Writer, opts ...Option) (*ConfigWriter, error) {
+	names := make([]string, 0, len(sinks))
+	for name := range sinks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cw := &ConfigWriter{
+		path:   path,
+		prefix: l.Prefix(),
+		flags:  l.Flags(),
+		opts:   opts,
+		sinks:  make(map[string]*Switch, len(sinks)),
+	}
+	for _, name := range names {
+		sw := NewSwitch(sinks[name])
+		cw.sinks[name] = sw
+		cw.out = append(cw.out, sw)
+	}
+
+	if err := cw.Reload(); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+// Write implements io.Writer.
+func (cw *ConfigWriter) Write(p []byte) (int, error) {
+	return cw.cur.Load().(*logwriter).Write(p)
+}
+
+// WriteString implements io.StringWriter.
+func (cw *ConfigWriter) WriteString(s string) (int, error) {
+	return cw.cur.Load().(*logwriter).WriteString(s)
+}
+
+// Unwrap returns the sinks.
+func (cw *ConfigWriter) Unwrap() []io.Writer { return cw.out }
+
+// Config returns the configuration in effect.
+func (cw *ConfigWriter) Config() Config {
+	return cw.config.Load().(Config)
+}
+
+// Reload reads the configuration file and applies it.
+// The configuration in effect is kept if the file is invalid.
+func (cw *ConfigWriter) Reload() error {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	return cw.reload()
+}
+
+func (cw *ConfigWriter) reload() error {
+	fi, err := os.Stat(cw.path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(cw.path)
+	if err != nil {
+		return err
+	}
+	cw.modTime, cw.size = fi.ModTime(), fi.Size()
+
+	c, err := ParseConfig(data)
+	if err != nil {
+		return err
+	}
+	for name := range c.Sinks {
+		if cw.sinks[name] == nil {
+			return errors.New("slog: config: unknown sink " + name)
+		}
+	}
+
+	var level LevelVar
+	if c.Level != "" {
+		l, _ := ParseLevel(c.Level)
+		level.Set(l)
+	}
+	opts := append(cw.opts[:len(cw.opts):len(cw.opts)], c.options(&level)...)
+	lw := newLogwriter(cw.out, cw.prefix, cw.flags, opts)
+	lw.setColor(cw.out)
+	lw.mu = &cw.wmu
+
+	for name, sw := range cw.sinks {
+		on, ok := c.Sinks[name]
+		sw.Enable(on || !ok)
+	}
+	cw.cur.Store(lw)
+	cw.config.Store(c)
+	return nil
+}
+
+// Watch checks the configuration file for changes at every interval and reloads
+// it when its modification time or size changes. Errors are passed to onError,
+// which may be nil. The returned function stops watching.
+func (cw *ConfigWriter) Watch(interval time.Duration, onError func(error)) (stop func()) {
+	t := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-t.C:
+				if err := cw.check(); err != nil && onError != nil {
+					onError(err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			t.Stop()
+			close(done)
+		})
+	}
+}
+
+func (cw *ConfigWriter) check() error {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	fi, err := os.Stat(cw.path)
+	if err != nil {
+		return err
+	}
+	if fi.ModTime().Equal(cw.modTime) && fi.Size() == cw.size {
+		return nil
+	}
+	return cw.reload()
+}
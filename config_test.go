@@ -0,0 +1,72 @@
+package slog
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	for _, s := range []string{
+		`{"format":"xml"}`,
+		`{"level":"loud"}`,
+		`{"names":{"mesg":"msg"}}`,
+		`{"names":{"message":""}}`,
+		`{"colour":true}`,
+		`{`,
+	} {
+		if _, err := ParseConfig([]byte(s)); err == nil {
+			t.Fatal(s)
+		}
+	}
+}
+
+func TestConfigWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.json")
+	if err := os.WriteFile(path, []byte(`{"redact":["pass"],"names":{"prefix":"component"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var a, b bytes.Buffer
+	l := log.New(io.Discard, "api: ", Lparsefields)
+	cw, err := NewConfigWriter(path, l, map[string]io.Writer{"a": &a, "b": &b})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.SetOutput(cw)
+
+	l.Print("levl=debug user=bob")
+	l.Print("user=bob pass=secret")
+	want := `{"component":"api","user":"bob","pass":"[REDACTED]"}` + "\n"
+	if a.String() != want || b.String() != want {
+		t.Fatal(a.String(), b.String())
+	}
+
+	if err := os.WriteFile(path, []byte(`{"format":"logstash","level":"debug","sinks":{"b":false}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	mu := cw.cur.Load().(*logwriter).mu
+	if err := cw.Reload(); err != nil {
+		t.Fatal(err)
+	} else if cw.cur.Load().(*logwriter).mu != mu {
+		t.Fatal("configurations do not share the write lock")
+	}
+	a.Reset()
+	b.Reset()
+	l.Print("levl=debug hello")
+	if want := `{"@version":"1","prfx":"api","levl":"debug"}` + "\n"; a.String() != want || b.Len() != 0 {
+		t.Fatal(a.String(), b.String())
+	}
+
+	if err := os.WriteFile(path, []byte(`{"sinks":{"c":true}}`), 0o600); err == nil {
+		if err := cw.Reload(); err == nil {
+			t.Fatal("unknown sink accepted")
+		}
+	}
+	if cw.Config().Level != "debug" {
+		t.Fatal(cw.Config())
+	}
+}
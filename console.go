@@ -0,0 +1,110 @@
+package slog
+
+import (
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var levelColors = map[Level]string{
+	LevelTrace: "\033[90m",
+	LevelDebug: "\033[90m",
+	LevelInfo:  "\033[32m",
+	LevelWarn:  "\033[33m",
+	LevelError: "\033[31m",
+	LevelFatal: "\033[1;31m",
+}
+
+// Console additionally writes every entry to w as a human readable line
+// of the form "15:04:05.000 INFO  prfx: text key=value ...". The line is
+// formatted from the same parsed entry as the JSON output, after the hooks have run.
+// It is colorized if w is a terminal, or if ForceColor is set, unless NoColor is set
+// or the NO_COLOR environment variable is set. Flag Lcolor only affects the JSON output.
+func Console(w io.Writer) Option {
+	return func(l *logwriter) {
+		l.console = w
+	}
+}
+
+// NewDual creates a logger that writes human readable lines to console,
+// typically a terminal, and compact JSON entries to w, typically a file or socket.
+// Every line is parsed once for both outputs. See Console.
+func NewDual(console, w io.Writer, prefix string, flag int, opts ...Option) *log.Logger {
+	opts = append(opts[:len(opts):len(opts)], Console(console))
+	return New(w, prefix, flag, opts...)
+}
+
+// setConsoleColor enables colors on the console output.
+func (l *logwriter) setConsoleColor() {
+	l.consoleCol = plain
+	switch l.colorMode {
+	case colorForce:
+		l.consoleCol = color
+	case colorAuto:
+		if os.Getenv("NO_COLOR") == "" && isterm(l.console) {
+			l.consoleCol = color
+		}
+	}
+}
+
+// appendConsole formats the entry as a human readable line.
+func (l *logwriter) appendConsole(dst []byte, e *Entry, text string) []byte {
+	col := l.consoleCol
+
+	if l.flags&(log.Ldate|log.Ltime|log.Lmicroseconds|Ltimestamp) != 0 && !e.Time.IsZero() {
+		dst = col(dst, "\033[90m")
+		dst = e.Time.AppendFormat(dst, "15:04:05.000")
+		dst = col(dst, clrcol)
+		dst = append(dst, ' ')
+	}
+
+	if level, ok := entryLevel(e, text); ok {
+		name := strings.ToUpper(level.String())
+		dst = col(dst, levelColors[level])
+		dst = append(dst, name...)
+		dst = col(dst, clrcol)
+		for i := len(name); i < 5; i++ {
+			dst = append(dst, ' ')
+		}
+		dst = append(dst, ' ')
+	}
+
+	if e.Prefix != "" {
+		dst = append(dst, e.Prefix...)
+		dst = append(dst, ": "...)
+	}
+
+	if e.File != "" {
+		dst = append(dst, e.File...)
+		dst = append(dst, ':')
+		dst = strconv.AppendInt(dst, int64(e.Line), 10)
+		dst = append(dst, ": "...)
+	}
+
+	mesg := e.Message
+	if l.flags&Lparsefields != 0 {
+		mesg = freeText(mesg)
+	}
+	dst = append(dst, mesg...)
+
+	for _, f := range e.Fields {
+		if isLevelKey(f.Key) {
+			continue
+		}
+		if len(dst) > 0 && dst[len(dst)-1] != ' ' {
+			dst = append(dst, ' ')
+		}
+		dst = col(dst, keycol)
+		dst = append(dst, f.Key...)
+		dst = col(dst, clrcol)
+		dst = append(dst, '=')
+		dst = append(dst, quoteValue(f.Value.String())...)
+	}
+
+	for len(dst) > 0 && dst[len(dst)-1] == ' ' {
+		dst = dst[:len(dst)-1]
+	}
+	return append(dst, '\n')
+}
@@ -0,0 +1,33 @@
+package slog
+
+import (
+	"bytes"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestNewDual(t *testing.T) {
+	var console, b bytes.Buffer
+	clock := fixedClock(time.Date(2024, 1, 2, 3, 4, 5, 6e6, time.UTC))
+	l := NewDual(&console, &b, "api: ", log.Ltime|log.LUTC|Lparsefields|Lmessage, WithClock(clock))
+
+	l.Print("levl=warn disk almost full free=\"1 GB\"")
+	l.Print("hello")
+
+	if want := `{"prfx":"api","time":"03:04:05","mesg":"levl=warn disk almost full free=\"1 GB\"","levl":"warn","free":"1 GB"}` + "\n" +
+		`{"prfx":"api","time":"03:04:05","mesg":"hello"}` + "\n"; b.String() != want {
+		t.Fatal(b.String())
+	}
+	if want := "03:04:05.006 WARN  api: disk almost full free=\"1 GB\"\n" +
+		"03:04:05.006 api: hello\n"; console.String() != want {
+		t.Fatal(console.String())
+	}
+
+	console.Reset()
+	l = NewDual(&console, &b, "", Lparsefields, ForceColor())
+	l.Print("levl=error a=1")
+	if want := "\033[31mERROR\033[0m \033[34ma\033[0m=1\n"; console.String() != want {
+		t.Fatalf("%q", console.String())
+	}
+}
@@ -0,0 +1,63 @@
+package slog
+
+import (
+	"os"
+	"strings"
+)
+
+func isContainerIDSep(r rune) bool {
+	return r == '/' || r == '-' || r == '.' || r == ':' || r == ' ' || r == '\n'
+}
+
+// cgroupContainerID finds the first 64 digit hexadecimal id in the cgroup paths
+// of a process, such as /docker/<id> and /kubepods/.../cri-containerd-<id>.scope.
+func cgroupContainerID(cgroup string) string {
+	for _, tok := range strings.FieldsFunc(cgroup, isContainerIDSep) {
+		if len(tok) == 64 && isLowerHex(tok) {
+			return tok
+		}
+	}
+	return ""
+}
+
+// mountContainerID finds the container id in the mount points of a process,
+// such as /var/lib/docker/containers/<id>/hostname. Only the paths below
+// a containers directory are considered, because the mount points also
+// contain the ids of image layers.
+func mountContainerID(mountinfo string) string {
+	const dir = "/containers/"
+	for i := strings.Index(mountinfo, dir); i != -1; i = strings.Index(mountinfo, dir) {
+		mountinfo = mountinfo[i+len(dir):]
+		if len(mountinfo) >= 64 && isLowerHex(mountinfo[:64]) {
+			return mountinfo[:64]
+		}
+	}
+	return ""
+}
+
+// detectContainerID reads the container id from the cgroups of the process,
+// falling back to its mount points for cgroup v2 namespaces.
+func detectContainerID() string {
+	if p, err := os.ReadFile("/proc/self/cgroup"); err == nil {
+		if id := cgroupContainerID(string(p)); id != "" {
+			return id
+		}
+	}
+	if p, err := os.ReadFile("/proc/self/mountinfo"); err == nil {
+		return mountContainerID(string(p))
+	}
+	return ""
+}
+
+// ContainerID adds the id of the container that runs the process to every entry
+// in the container.id field. The id is detected once on Linux by parsing the
+// cgroup and mount paths of the process. The option does nothing
+// if the process does not run in a container or the id cannot be detected.
+func ContainerID() Option {
+	id := detectContainerID()
+	return func(l *logwriter) {
+		if id != "" {
+			l.static = append(l.static, Field{"container.id", StringValue(id)})
+		}
+	}
+}
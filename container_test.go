@@ -0,0 +1,33 @@
+package slog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestContainerID(t *testing.T) {
+	id := strings.Repeat("0123456789abcdef", 4)
+	layer := strings.Repeat("fedcba9876543210", 4)
+
+	for _, tt := range []struct {
+		cgroup, mountinfo string
+	}{
+		{"12:pids:/docker/" + id + "\n11:cpu:/docker/" + id, ""},
+		{"0::/system.slice/docker-" + id + ".scope", ""},
+		{"0::/kubepods/besteffort/pod1234/cri-containerd-" + id + ".scope", ""},
+		{"0::/", "1 0 0:1 / / rw - overlay overlay rw,upperdir=/var/lib/docker/overlay2/" + layer + "/diff\n" +
+			"2 1 0:2 /var/lib/docker/containers/" + id + "/hostname /etc/hostname rw"},
+	} {
+		got := cgroupContainerID(tt.cgroup)
+		if got == "" {
+			got = mountContainerID(tt.mountinfo)
+		}
+		if got != id {
+			t.Errorf("%q: got %q", tt.cgroup, got)
+		}
+	}
+
+	if got := cgroupContainerID("0::/user.slice"); got != "" {
+		t.Error(got)
+	}
+}
@@ -0,0 +1,132 @@
+package slog
+
+import (
+	"io"
+	"os"
+	"sync/atomic"
+)
+
+// LevelVar is a level that can be changed while the program runs.
+// It is safe for concurrent use. The zero value is LevelInfo.
+type LevelVar struct {
+	v int64
+}
+
+// Level returns the level.
+func (v *LevelVar) Level() Level {
+	if l := Level(atomic.LoadInt64(&v.v)); l != 0 {
+		return l
+	}
+	return LevelInfo
+}
+
+// Set changes the level.
+func (v *LevelVar) Set(l Level) {
+	atomic.StoreInt64(&v.v, int64(l))
+}
+
+// MinLevel drops the entries below the level of v.
+// Entries without a level are treated as info.
+func MinLevel(v *LevelVar) Option {
+	return BeforeWrite(func(e *Entry) bool {
+		level, ok := entryLevel(e, e.Message)
+		if !ok {
+			level = LevelInfo
+		}
+		return level >= v.Level()
+	})
+}
+
+// DefaultLevelEnv is the environment variable that is conventionally used for the level.
+const DefaultLevelEnv = "LOG_LEVEL"
+
+// LevelFromEnv returns a level variable that is initialized from the environment
+// variable with the name, or with def if the variable is unset or not a level name.
+// The environment thus takes precedence over the default of the program, and changes
+// made at runtime, such as by NewAdminHandler or LevelOnSignal, take precedence
+// over the environment. Writers that share the variable share the level, while
+// writers that need their own default use their own variable:
+//
+//	stderr := slog.New(os.Stderr, "", slog.LstdFlags,
+//		slog.MinLevel(slog.LevelFromEnv(slog.DefaultLevelEnv, slog.LevelInfo)))
+//	audit := slog.New(file, "", slog.LstdFlags,
+//		slog.MinLevel(slog.LevelFromEnv("AUDIT_LOG_LEVEL", slog.LevelDebug)))
+func LevelFromEnv(name string, def Level) *LevelVar {
+	var v LevelVar
+	v.Set(def)
+	if level, ok := ParseLevel(os.Getenv(name)); ok {
+		v.Set(level)
+	}
+	return &v
+}
+
+// SampleVar is a sampling rate that can be changed while the program runs.
+// It is safe for concurrent use. The zero value keeps all entries.
+type SampleVar struct {
+	rate  int64
+	count uint64
+}
+
+// Rate returns the sampling rate.
+func (v *SampleVar) Rate() int {
+	return int(atomic.LoadInt64(&v.rate))
+}
+
+// Set changes the sampling rate. A rate of n keeps one in n entries.
+// All entries are kept if n is less than two.
+func (v *SampleVar) Set(n int) {
+	atomic.StoreInt64(&v.rate, int64(n))
+}
+
+func (v *SampleVar) keep() bool {
+	rate := atomic.LoadInt64(&v.rate)
+	return rate < 2 || atomic.AddUint64(&v.count, 1)%uint64(rate) == 0
+}
+
+// Sampling keeps one in every n entries below the warn level, where n is the rate of v.
+// Warnings and errors are never dropped.
+func Sampling(v *SampleVar) Option {
+	return BeforeWrite(func(e *Entry) bool {
+		if level, ok := entryLevel(e, e.Message); ok && level >= LevelWarn {
+			return true
+		}
+		return v.keep()
+	})
+}
+
+// Switch is a writer that can be turned off while the program runs.
+// Entries written while it is off are discarded. It is on initially.
+type Switch struct {
+	w   io.Writer
+	off uint32
+}
+
+// NewSwitch creates a switch that writes to w.
+func NewSwitch(w io.Writer) *Switch {
+	return &Switch{w: w}
+}
+
+// Write writes p to the output writer if the switch is on.
+func (s *Switch) Write(p []byte) (int, error) {
+	if atomic.LoadUint32(&s.off) != 0 {
+		return len(p), nil
+	}
+	return s.w.Write(p)
+}
+
+// Unwrap returns the output writer.
+func (s *Switch) Unwrap() io.Writer { return s.w }
+
+// Enabled reports whether the switch is on.
+func (s *Switch) Enabled() bool {
+	return atomic.LoadUint32(&s.off) == 0
+}
+
+// Enable turns the switch on or off.
+func (s *Switch) Enable(on bool) {
+	var off uint32
+	if !on {
+		off = 1
+	}
+	atomic.StoreUint32(&s.off, off)
+}
@@ -0,0 +1,70 @@
+package slog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMinLevel(t *testing.T) {
+	var level LevelVar
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields, MinLevel(&level))
+
+	l.Print("levl=debug a=1")
+	l.Print("levl=info a=2")
+	level.Set(LevelDebug)
+	l.Print("levl=debug a=3")
+	level.Set(LevelError)
+	l.Print("a=4")
+
+	if want := `{"levl":"info","a":2}` + "\n" + `{"levl":"debug","a":3}` + "\n"; b.String() != want {
+		t.Fatal(b.String())
+	}
+}
+
+func TestLevelFromEnv(t *testing.T) {
+	defer setenv("TEST_LOG_LEVEL", "WARNING")()
+	if v := LevelFromEnv("TEST_LOG_LEVEL", LevelDebug); v.Level() != LevelWarn {
+		t.Fatal(v.Level())
+	}
+	defer setenv("TEST_LOG_LEVEL", "loud")()
+	if v := LevelFromEnv("TEST_LOG_LEVEL", LevelDebug); v.Level() != LevelDebug {
+		t.Fatal(v.Level())
+	}
+	if v := LevelFromEnv("TEST_UNSET_LOG_LEVEL", LevelError); v.Level() != LevelError {
+		t.Fatal(v.Level())
+	}
+}
+
+func TestSampling(t *testing.T) {
+	var rate SampleVar
+	rate.Set(3)
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields, Sampling(&rate))
+
+	for i := 0; i < 9; i++ {
+		l.Print("levl=info")
+	}
+	l.Print("levl=error")
+
+	if n := strings.Count(b.String(), "info"); n != 3 {
+		t.Fatal(n)
+	}
+	if !strings.Contains(b.String(), "error") {
+		t.Fatal("error sampled")
+	}
+}
+
+func TestSwitch(t *testing.T) {
+	var b bytes.Buffer
+	s := NewSwitch(&b)
+	s.Write([]byte("a"))
+	s.Enable(false)
+	s.Write([]byte("b"))
+	s.Enable(true)
+	s.Write([]byte("c"))
+	if b.String() != "ac" || !s.Enabled() {
+		t.Fatal(b.String())
+	}
+}
@@ -0,0 +1,145 @@
+package slog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Decoder reads structured log entries from an input stream.
+// It understands the default field names and the Logstash field names.
+// Fields that are not produced by the parser itself are stored in Entry.Fields
+// in the order they appear. Values that are objects or arrays are stored
+// as strings containing their JSON representation.
+type Decoder struct {
+	r    *bufio.Reader
+	line int
+}
+
+// NewDecoder returns a new decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r)}
+}
+
+var timeLayouts = [...]string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+	"15:04:05.999999999",
+}
+
+func parseJSONTime(s string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func jsonValue(raw json.RawMessage) (Value, error) {
+	switch raw[0] {
+	case '"':
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return StringValue(s), err
+	case 't':
+		return BoolValue(true), nil
+	case 'f':
+		return BoolValue(false), nil
+	case 'n':
+		return NullValue(), nil
+	case '{', '[':
+		return StringValue(string(raw)), nil
+	}
+	s := string(raw)
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return IntValue(i), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	return FloatValue(f), err
+}
+
+// Decode reads the next entry into e.
+// It returns io.EOF when there are no more entries.
+func (d *Decoder) Decode(e *Entry) error {
+	var line []byte
+	for len(line) == 0 {
+		var err error
+		line, err = d.r.ReadBytes('\n')
+		if err != nil && (err != io.EOF || len(line) == 0) {
+			return err
+		}
+		d.line++
+		line = bytes.TrimSpace(line)
+	}
+
+	// sd-daemon priority prefix
+	if len(line) > 3 && line[0] == '<' && line[2] == '>' {
+		line = line[3:]
+	}
+
+	*e = Entry{Fields: e.Fields[:0]}
+
+	dec := json.NewDecoder(bytes.NewReader(line))
+	if tok, err := dec.Token(); err != nil {
+		return d.errorf(err)
+	} else if tok != json.Delim('{') {
+		return d.errorf(errors.New("not an object"))
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return d.errorf(err)
+		}
+		key, _ := tok.(string)
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return d.errorf(err)
+		}
+
+		val, err := jsonValue(raw)
+		if err != nil {
+			return d.errorf(err)
+		}
+
+		switch key {
+		case defaultNames.prefix:
+			e.Prefix = val.String()
+		case defaultNames.time, "@timestamp":
+			if t, ok := parseJSONTime(val.String()); ok {
+				e.Time = t
+			}
+		case defaultNames.file:
+			e.File = val.String()
+		case defaultNames.line:
+			e.Line = int(val.Int())
+		case defaultNames.function:
+			e.Func = val.String()
+		case defaultNames.message, "message":
+			e.Message = val.String()
+		case defaultNames.text:
+			if e.Message == "" {
+				e.Message = val.String()
+			}
+		case defaultNames.raw:
+			e.Raw = val.String()
+		case "@version":
+		default:
+			e.Fields = append(e.Fields, Field{key, val})
+		}
+	}
+
+	return nil
+}
+
+func (d *Decoder) errorf(err error) error {
+	return fmt.Errorf("slog: line %d: %w", d.line, err)
+}
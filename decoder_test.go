@@ -0,0 +1,54 @@
+package slog
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"testing"
+)
+
+func TestDecoderRoundTrip(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "app: ", log.LstdFlags|log.Lmicroseconds|log.LUTC|log.Lshortfile|Lparsefields|Lmessage)
+	l.Println("hello a=1 b=\"x y\" c=2.5 d=false e=<nil>")
+	l.Println("world")
+
+	d := NewDecoder(&b)
+
+	var e Entry
+	if err := d.Decode(&e); err != nil {
+		t.Fatal(err)
+	} else if e.Prefix != "app" || e.Time.IsZero() || e.File != "decoder_test.go" || e.Line == 0 {
+		t.Fatal(e)
+	} else if e.Message != "hello a=1 b=\"x y\" c=2.5 d=false e=<nil>" || len(e.Fields) != 5 {
+		t.Fatal(e)
+	} else if v, _ := e.Get("a"); v.Int() != 1 {
+		t.Fatal(v)
+	} else if v, _ := e.Get("b"); v.String() != "x y" {
+		t.Fatal(v)
+	} else if v, _ := e.Get("c"); v.Float() != 2.5 {
+		t.Fatal(v)
+	} else if v, _ := e.Get("e"); v.Kind() != KindNull {
+		t.Fatal(v)
+	}
+
+	if err := d.Decode(&e); err != nil {
+		t.Fatal(err)
+	} else if e.Message != "world" || len(e.Fields) != 0 {
+		t.Fatal(e)
+	}
+
+	if err := d.Decode(&e); err != io.EOF {
+		t.Fatal(err)
+	}
+}
+
+func TestDecoderMalformed(t *testing.T) {
+	var e Entry
+	d := NewDecoder(bytes.NewBufferString("{\"mesg\":\"a\"}\n[1]\n"))
+	if err := d.Decode(&e); err != nil {
+		t.Fatal(err)
+	} else if err := d.Decode(&e); err == nil || err.Error() != "slog: line 2: not an object" {
+		t.Fatal(err)
+	}
+}
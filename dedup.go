@@ -0,0 +1,167 @@
+package slog
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DedupOptions configures a DedupWriter.
+type DedupOptions struct {
+	// IgnoreKeys are the string fields that are ignored when comparing entries.
+	// Defaults to the time field.
+	IgnoreKeys []string
+
+	// Interval is the maximum time that the summary of a run of repeated
+	// entries is held back. The summary is written when a different entry
+	// arrives, at every interval and when the writer is flushed or closed.
+	// If zero, it is only written when a different entry arrives or on flush.
+	Interval time.Duration
+}
+
+// DedupWriter suppresses identical consecutive entries, such as those logged
+// by retry loops and flapping conditions. It is safe for concurrent use.
+type DedupWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	opts    DedupOptions
+	key     []byte
+	last    []byte
+	repeats int
+	err     error
+	done    chan struct{}
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+// NewDedupWriter creates a writer that writes the first of a run of identical
+// consecutive entries to w and suppresses the rest. When the run ends, the last
+// entry of the run is written with the number of suppressed entries in the
+// repeat_count field. Entries are identical if they are equal apart from the
+// ignored fields. The repeat_count field invalidates signatures made by SignHMAC.
+func NewDedupWriter(w io.Writer, opts DedupOptions) *DedupWriter {
+	if opts.IgnoreKeys == nil {
+		opts.IgnoreKeys = []string{"time"}
+	}
+	d := &DedupWriter{w: w, opts: opts, done: make(chan struct{})}
+	if opts.Interval > 0 {
+		d.wg.Add(1)
+		go d.loop()
+	}
+	return d
+}
+
+func (d *DedupWriter) loop() {
+	defer d.wg.Done()
+	t := time.NewTicker(d.opts.Interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			d.mu.Lock()
+			if err := d.flushLocked(); err != nil {
+				d.err = err
+			}
+			d.mu.Unlock()
+		case <-d.done:
+			return
+		}
+	}
+}
+
+// stripString removes the string field with the key from an encoded entry.
+func stripString(line []byte, key string) []byte {
+	field := `"` + key + `":"`
+	i := bytes.Index(line, []byte(field))
+	if i == -1 {
+		return line
+	}
+	j := bytes.IndexByte(line[i+len(field):], '"')
+	if j == -1 {
+		return line
+	}
+	end := i + len(field) + j + 1
+	if end < len(line) && line[end] == ',' {
+		end++
+	}
+	return append(line[:i:i], line[end:]...)
+}
+
+func (d *DedupWriter) dedupKey(dst, p []byte) []byte {
+	dst = append(dst[:0], p...)
+	for _, key := range d.opts.IgnoreKeys {
+		dst = stripString(dst, key)
+	}
+	return dst
+}
+
+// flushLocked writes the summary of the current run, if any.
+func (d *DedupWriter) flushLocked() error {
+	if d.repeats == 0 {
+		return nil
+	}
+	line := bytes.TrimRight(d.last, "\n")
+	var summary []byte
+	if n := len(line); n > 0 && line[n-1] == '}' {
+		summary = append(summary, line[:n-1]...)
+		if n > 2 {
+			summary = append(summary, ',')
+		}
+		summary = append(summary, `"repeat_count":`...)
+		summary = strconv.AppendInt(summary, int64(d.repeats), 10)
+		summary = append(summary, "}\n"...)
+	} else {
+		summary = append(summary, d.last...)
+	}
+	d.repeats = 0
+	_, err := d.w.Write(summary)
+	return err
+}
+
+// Write writes p unless it repeats the previous entry.
+// It returns the error of a previous asynchronous flush, if any.
+func (d *DedupWriter) Write(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := d.err; err != nil {
+		d.err = nil
+		return 0, err
+	}
+
+	key := d.dedupKey(nil, p)
+	if d.last != nil && bytes.Equal(key, d.key) {
+		d.last = append(d.last[:0], p...)
+		d.repeats++
+		return len(p), nil
+	}
+
+	if err := d.flushLocked(); err != nil {
+		return 0, err
+	}
+	d.key = key
+	d.last = append(d.last[:0], p...)
+	return d.w.Write(p)
+}
+
+// Unwrap returns the output writer.
+func (d *DedupWriter) Unwrap() io.Writer { return d.w }
+
+// Flush writes the summary of the current run of repeated entries, if any.
+func (d *DedupWriter) Flush() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.flushLocked()
+}
+
+// Close stops the interval flushes and writes the summary of the current run.
+// It does not close the output writer.
+func (d *DedupWriter) Close() error {
+	d.once.Do(func() {
+		close(d.done)
+		d.wg.Wait()
+	})
+	return d.Flush()
+}
@@ -0,0 +1,49 @@
+package slog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestDedupWriter(t *testing.T) {
+	var b bytes.Buffer
+	d := NewDedupWriter(&b, DedupOptions{})
+	for _, line := range []string{
+		`{"time":"2024-01-01T00:00:01Z","mesg":"retry","n":1}`,
+		`{"time":"2024-01-01T00:00:02Z","mesg":"retry","n":1}`,
+		`{"time":"2024-01-01T00:00:03Z","mesg":"retry","n":1}`,
+		`{"time":"2024-01-01T00:00:04Z","mesg":"ok"}`,
+		`{"time":"2024-01-01T00:00:05Z","mesg":"ok"}`,
+	} {
+		if _, err := d.Write([]byte(line + "\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"time":"2024-01-01T00:00:01Z","mesg":"retry","n":1}` + "\n" +
+		`{"time":"2024-01-01T00:00:03Z","mesg":"retry","n":1,"repeat_count":2}` + "\n" +
+		`{"time":"2024-01-01T00:00:04Z","mesg":"ok"}` + "\n" +
+		`{"time":"2024-01-01T00:00:05Z","mesg":"ok","repeat_count":1}` + "\n"
+	if b.String() != want {
+		t.Fatal(b.String())
+	}
+}
+
+func TestDedupWriterInterval(t *testing.T) {
+	var b bytes.Buffer
+	d := NewDedupWriter(&b, DedupOptions{Interval: 5 * time.Millisecond})
+	defer d.Close()
+	d.Write([]byte("{\"a\":1}\n"))
+	d.Write([]byte("{\"a\":1}\n"))
+	time.Sleep(50 * time.Millisecond)
+	d.mu.Lock()
+	got := b.String()
+	d.mu.Unlock()
+	if want := "{\"a\":1}\n{\"a\":1,\"repeat_count\":1}\n"; got != want {
+		t.Fatal(got)
+	}
+}
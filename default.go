@@ -0,0 +1,96 @@
+package slog
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+var (
+	defaultMu     sync.Mutex
+	defaultLogger *log.Logger
+)
+
+// Default returns the package-level logger that is used by the package-level
+// print functions. It is lazily created to write structured logs to os.Stderr
+// with LstdFlags, unless it is replaced by SetDefault.
+func Default() *log.Logger {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if defaultLogger == nil {
+		defaultLogger = New(os.Stderr, "", LstdFlags)
+	}
+	return defaultLogger
+}
+
+// SetDefault replaces the package-level logger.
+func SetDefault(l *log.Logger) {
+	defaultMu.Lock()
+	defaultLogger = l
+	defaultMu.Unlock()
+}
+
+// Print calls Print on the default logger.
+func Print(v ...interface{}) { _ = Default().Output(2, fmt.Sprint(v...)) }
+
+// Printf calls Printf on the default logger.
+func Printf(format string, v ...interface{}) { _ = Default().Output(2, fmt.Sprintf(format, v...)) }
+
+// Println calls Println on the default logger.
+func Println(v ...interface{}) { _ = Default().Output(2, fmt.Sprintln(v...)) }
+
+// Fatal is equivalent to Print followed by a call to os.Exit(1).
+func Fatal(v ...interface{}) {
+	_ = Default().Output(2, fmt.Sprint(v...))
+	os.Exit(1)
+}
+
+// Fatalf is equivalent to Printf followed by a call to os.Exit(1).
+func Fatalf(format string, v ...interface{}) {
+	_ = Default().Output(2, fmt.Sprintf(format, v...))
+	os.Exit(1)
+}
+
+// Fatalln is equivalent to Println followed by a call to os.Exit(1).
+func Fatalln(v ...interface{}) {
+	_ = Default().Output(2, fmt.Sprintln(v...))
+	os.Exit(1)
+}
+
+// Panic is equivalent to Print followed by a call to panic.
+func Panic(v ...interface{}) {
+	s := fmt.Sprint(v...)
+	_ = Default().Output(2, s)
+	panic(s)
+}
+
+// Panicf is equivalent to Printf followed by a call to panic.
+func Panicf(format string, v ...interface{}) {
+	s := fmt.Sprintf(format, v...)
+	_ = Default().Output(2, s)
+	panic(s)
+}
+
+// Panicln is equivalent to Println followed by a call to panic.
+func Panicln(v ...interface{}) {
+	s := fmt.Sprintln(v...)
+	_ = Default().Output(2, s)
+	panic(s)
+}
+
+// wrapperFuncs are the functions of this package that log on behalf of their caller.
+// They are skipped by Lfuncname.
+var wrapperFuncs = map[string]bool{}
+
+func registerWrappers(fns ...interface{}) {
+	for _, fn := range fns {
+		wrapperFuncs[runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()] = true
+	}
+}
+
+func init() {
+	registerWrappers(Print, Printf, Println, Fatal, Fatalf, Fatalln, Panic, Panicf, Panicln)
+}
@@ -0,0 +1,23 @@
+package slog
+
+import (
+	"bytes"
+	"log"
+	"testing"
+)
+
+func TestDefault(t *testing.T) {
+	defer SetDefault(nil)
+
+	if Default() != Default() {
+		t.Fatal()
+	}
+
+	var b bytes.Buffer
+	SetDefault(New(&b, "", log.Lshortfile|Lfuncname|Lmessage))
+	Printf("hello %s", "world")
+
+	if exp := "{\"fnam\":\"default_test.go\",\"flno\":18,\"func\":\"slog.TestDefault\",\"mesg\":\"hello world\"}\n"; b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
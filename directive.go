@@ -0,0 +1,98 @@
+package slog
+
+import (
+	"io"
+	"log"
+	"strings"
+	"unicode"
+)
+
+// directive holds the overrides of a single entry.
+type directive struct {
+	level Level
+	keep  bool
+	sink  io.Writer
+}
+
+// Directives enables per-entry directives. A message that starts with a directive
+// of the form !{key=value ...} is written with the overrides of the directive,
+// which is removed from the message. The directive keys are:
+//
+//	level=<level>  sets the level of the entry
+//	keep           writes the entry even if a hook drops it, such as MinLevel or Sampling
+//	sink=<name>    writes the entry to the named sink instead of the output writer
+//
+// For example, to mark an audit entry that bypasses sampling:
+//
+//	l.Print("!{level=warn keep sink=audit} user=bob action=delete")
+//
+// A directive with an unknown key, level or sink is not a directive and is left in the message.
+func Directives(sinks map[string]io.Writer) Option {
+	return func(l *logwriter) {
+		l.directives = true
+		l.sinks = sinks
+	}
+}
+
+// parseDirective parses the directive at the start of s and returns the
+// remainder of s. It returns false if s does not start with a valid directive.
+func (l *logwriter) parseDirective(s string) (d directive, rest string, ok bool) {
+	if !strings.HasPrefix(s, "!{") {
+		return d, s, false
+	}
+	end := strings.IndexByte(s, '}')
+	if end == -1 {
+		return d, s, false
+	}
+	for _, arg := range strings.Fields(s[2:end]) {
+		key, val := arg, ""
+		if i := strings.IndexByte(arg, '='); i != -1 {
+			key, val = arg[:i], arg[i+1:]
+		}
+		switch {
+		case key == "level" && val != "":
+			if d.level, ok = ParseLevel(val); !ok {
+				return d, s, false
+			}
+		case key == "keep" && val == "":
+			d.keep = true
+		case key == "sink" && val != "":
+			if d.sink = l.sinks[val]; d.sink == nil {
+				return d, s, false
+			}
+		default:
+			return d, s, false
+		}
+	}
+	return d, strings.TrimPrefix(s[end+1:], " "), true
+}
+
+// stripDirective removes the directive from the start of the message of the
+// parsed entry e, whose log line is s, and returns the log line without it.
+func (l *logwriter) stripDirective(e *Entry, s string) (string, directive, bool) {
+	mesg := e.Message
+	if l.prefix != "" && l.flags&log.Lmsgprefix != 0 {
+		mesg = strings.TrimPrefix(mesg, l.prefix)
+	}
+	d, rest, ok := l.parseDirective(mesg)
+	if !ok {
+		return s, d, false
+	}
+	start := len(strings.TrimRightFunc(s, unicode.IsSpace)) - len(mesg)
+	return s[:start] + rest + s[start+len(mesg):], d, true
+}
+
+// setLevel sets the level fields of the entry, or inserts a levl field if there are none.
+func setLevel(e *Entry, level Level) {
+	val := StringValue(level.String())
+	found := false
+	for i := range e.Fields {
+		if isLevelKey(e.Fields[i].Key) {
+			e.Fields[i].Value = val
+			found = true
+		}
+	}
+	if !found {
+		e.insert(0, Field{"levl", val})
+	}
+}
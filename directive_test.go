@@ -0,0 +1,32 @@
+package slog
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"testing"
+)
+
+func TestDirectives(t *testing.T) {
+	var b, audit bytes.Buffer
+	var level LevelVar
+	level.Set(LevelWarn)
+	l := New(&b, "api: ", log.Lmsgprefix|Lparsefields|Lmessage,
+		Directives(map[string]io.Writer{"audit": &audit}), MinLevel(&level))
+
+	l.Print("!{level=info} dropped")
+	l.Print("!{level=debug keep} a=1")
+	l.Print("!{level=error sink=audit} user=bob")
+	l.Print("!{sink=nowhere} levl=warn")
+	l.Print("!{keep}")
+
+	want := `{"mesg":"api: a=1","levl":"debug","a":1}` + "\n" +
+		`{"mesg":"api: !{sink=nowhere} levl=warn","!{sink":"nowhere}","levl":"warn"}` + "\n" +
+		`{"mesg":"api:"}` + "\n"
+	if b.String() != want {
+		t.Fatal(b.String())
+	}
+	if want := `{"mesg":"api: user=bob","levl":"error","user":"bob"}` + "\n"; audit.String() != want {
+		t.Fatal(audit.String())
+	}
+}
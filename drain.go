@@ -0,0 +1,51 @@
+package slog
+
+import (
+	"context"
+	"io"
+)
+
+// Drainer is implemented by writers that buffer or queue entries,
+// such as AsyncWriter, FileWriter and the batching network writers.
+type Drainer interface {
+	// Drain writes the buffered entries and releases the resources of the writer.
+	// It gives up when the context is done and returns its error.
+	Drain(ctx context.Context) error
+}
+
+// drainFunc runs close in the background and waits for it to return
+// or for the context to be done.
+func drainFunc(ctx context.Context, close func() error) error {
+	errc := make(chan error, 1)
+	go func() { errc <- close() }()
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Drain drains the writers that implement Drainer and closes the writers
+// that implement io.Closer, in order, for use during service shutdown.
+// It returns the first error, but continues with the remaining writers
+// until the context is done.
+func Drain(ctx context.Context, writers ...io.Writer) error {
+	var first error
+	for _, w := range writers {
+		var err error
+		switch w := w.(type) {
+		case Drainer:
+			err = w.Drain(ctx)
+		case io.Closer:
+			err = drainFunc(ctx, w.Close)
+		}
+		if err != nil && first == nil {
+			first = err
+		}
+		if ctx.Err() != nil {
+			return first
+		}
+	}
+	return first
+}
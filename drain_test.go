@@ -0,0 +1,39 @@
+package slog
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDrain(t *testing.T) {
+	var b bytes.Buffer
+	var rec firehoseRecorder
+	a := NewAsyncWriter(&b, AsyncOptions{})
+	f := NewFirehoseWriter(&rec, "logs", FirehoseOptions{})
+
+	_, _ = a.Write([]byte("a\n"))
+	_, _ = f.Write([]byte("b\n"))
+
+	if err := Drain(context.Background(), a, f, &b); err != nil {
+		t.Fatal(err)
+	} else if b.String() != "a\n" || len(rec.batches) != 1 {
+		t.Fatal(b.String(), rec.batches)
+	}
+}
+
+func TestDrainDeadline(t *testing.T) {
+	w := blockingWriter{release: make(chan struct{})}
+	defer close(w.release)
+
+	a := NewAsyncWriter(&w, AsyncOptions{})
+	_, _ = a.Write([]byte("a\n"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := Drain(ctx, a); err != context.DeadlineExceeded {
+		t.Fatal(err)
+	}
+}
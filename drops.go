@@ -0,0 +1,112 @@
+package slog
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Reasons for dropping entries.
+const (
+	DropFiltered  = "filtered"
+	DropSampled   = "sampled"
+	DropRateLimit = "ratelimited"
+	DropOverflow  = "overflow"
+	DropBreaker   = "breaker"
+)
+
+type dropKey struct {
+	reason string
+	level  Level
+}
+
+// DropCounter counts the entries that were dropped by filters, samplers,
+// rate limiters, full queues and open circuit breakers, by reason and level,
+// so that operators know that data is missing. It is safe for concurrent use.
+type DropCounter struct {
+	mu     sync.Mutex
+	counts map[dropKey]uint64
+	total  uint64
+}
+
+// NewDropCounter creates a new DropCounter.
+func NewDropCounter() *DropCounter {
+	return &DropCounter{counts: map[dropKey]uint64{}}
+}
+
+// Add counts a dropped entry. It does nothing if c is nil.
+func (c *DropCounter) Add(reason string, level Level) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.counts[dropKey{reason, level}]++
+	c.total++
+	c.mu.Unlock()
+}
+
+// Total returns the total number of dropped entries.
+func (c *DropCounter) Total() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.total
+}
+
+// take returns and resets the counts.
+func (c *DropCounter) take() map[dropKey]uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counts := c.counts
+	c.counts = map[dropKey]uint64{}
+	return counts
+}
+
+// Report logs a summary entry to l for every reason and level with dropped entries
+// every interval, of the form "dropped 1523 debug entries in last 1m0s",
+// with the fields dropped, droplevel, reason and period.
+// The returned function stops reporting.
+func (c *DropCounter) Report(l *log.Logger, every time.Duration) (stop func()) {
+	done := make(chan struct{})
+	var once sync.Once
+	go func() {
+		t := time.NewTicker(every)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				c.report(l, every)
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() { once.Do(func() { close(done) }) }
+}
+
+func (c *DropCounter) report(l *log.Logger, every time.Duration) {
+	counts := c.take()
+
+	keys := make([]dropKey, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].reason != keys[j].reason {
+			return keys[i].reason < keys[j].reason
+		}
+		return keys[i].level < keys[j].level
+	})
+
+	for _, k := range keys {
+		l.Printf("dropped %d %s entries in last %s dropped=%d droplevel=%s reason=%s period=%s",
+			counts[k], k.level, every, counts[k], k.level, k.reason, every)
+	}
+}
+
+// CountDrops counts the entries that are dropped by BeforeWrite hooks as filtered.
+func CountDrops(c *DropCounter) Option {
+	return func(l *logwriter) {
+		l.drops = c
+	}
+}
@@ -0,0 +1,41 @@
+package slog
+
+import (
+	"bytes"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestDropCounter(t *testing.T) {
+	drops := NewDropCounter()
+
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields, CountDrops(drops), BeforeWrite(func(e *Entry) bool {
+		v, _ := e.Get("level")
+		return v.String() != "debug"
+	}))
+
+	l.Println("level=debug a=1")
+	l.Println("level=debug a=2")
+	l.Println("level=info a=3")
+	drops.Add(DropOverflow, LevelError)
+
+	if drops.Total() != 3 {
+		t.Fatal(drops.Total())
+	}
+
+	var r bytes.Buffer
+	drops.report(log.New(&r, "", 0), time.Minute)
+	exp := "dropped 2 debug entries in last 1m0s dropped=2 droplevel=debug reason=filtered period=1m0s\n" +
+		"dropped 1 error entries in last 1m0s dropped=1 droplevel=error reason=overflow period=1m0s\n"
+	if r.String() != exp {
+		t.Fatal(r.String())
+	}
+
+	r.Reset()
+	drops.report(log.New(&r, "", 0), time.Minute)
+	if r.Len() != 0 {
+		t.Fatal(r.String())
+	}
+}
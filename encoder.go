@@ -0,0 +1,40 @@
+package slog
+
+// Encoder encodes entries in the format written by a logger created by New,
+// so that other logging frontends can produce the same output without
+// formatting and parsing a log line. It is safe for concurrent use.
+type Encoder struct {
+	lw *logwriter
+}
+
+// NewEncoder creates an encoder with the same flags and options as New.
+// The flags that control parsing, such as Lparsefields and Lmsgprefix, have no effect.
+// Options that keep state between entries, such as HashChain, see every entry
+// encoded by the encoder.
+func NewEncoder(flags int, opts ...Option) *Encoder {
+	lw := newLogwriter(nil, "", flags, opts)
+	lw.buf = nil
+	return &Encoder{lw}
+}
+
+// AppendEntry adds the static fields of the options to a copy of e, runs the hooks on the copy
+// and appends its JSON encoding followed by a newline to dst. e is not modified, so that it can
+// be encoded again. It returns dst unchanged if a hook drops the entry.
+// The after write hooks are not called.
+func (enc *Encoder) AppendEntry(dst []byte, e *Entry) []byte {
+	entry := *e
+	entry.Fields = make([]Field, 0, len(e.Fields)+len(enc.lw.static))
+	entry.Fields = append(append(entry.Fields, e.Fields...), enc.lw.static...)
+
+	enc.lw.mu.Lock()
+	defer enc.lw.mu.Unlock()
+	dst, _ = enc.lw.appendLine(dst, &entry, entry.Message)
+	return dst
+}
+
+// AppendEntry appends the JSON encoding of e followed by a newline to dst
+// as written by a logger with the flags and options.
+// Use an Encoder to encode many entries or if the options keep state between entries.
+func AppendEntry(dst []byte, e *Entry, flags int, opts ...Option) []byte {
+	return NewEncoder(flags, opts...).AppendEntry(dst, e)
+}
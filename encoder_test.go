@@ -0,0 +1,60 @@
+package slog
+
+import (
+	"bytes"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestAppendEntry(t *testing.T) {
+	e := Entry{
+		Time:    time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC),
+		Prefix:  "app",
+		Message: "hello",
+		Fields:  []Field{{"a", IntValue(1)}},
+	}
+
+	got := AppendEntry([]byte("> "), &e, log.LstdFlags|log.LUTC|Lmessage)
+	if want := `> {"prfx":"app","time":"2021-02-03T04:05:06Z","mesg":"hello","a":1}` + "\n"; string(got) != want {
+		t.Fatal(string(got))
+	}
+}
+
+func TestEncoderHooks(t *testing.T) {
+	enc := NewEncoder(Lmessage, BeforeWrite(func(e *Entry) bool {
+		return e.Message != "drop"
+	}), HashChain(0))
+
+	e := Entry{Message: "drop"}
+	if got := enc.AppendEntry(nil, &e); len(got) != 0 {
+		t.Fatal(string(got))
+	}
+
+	var dst []byte
+	for _, msg := range []string{"a", "b", "c"} {
+		e = Entry{Message: msg}
+		dst = enc.AppendEntry(dst, &e)
+	}
+	if n, err := VerifyChain(bytes.NewReader(dst)); err != nil || n != 3 {
+		t.Fatal(n, err, string(dst))
+	}
+}
+
+func TestEncoderReuseEntry(t *testing.T) {
+	static := func(l *logwriter) {
+		l.static = append(l.static, Field{"svc", StringValue("api")})
+	}
+	enc := NewEncoder(Lmessage, static)
+
+	e := Entry{Message: "hello", Fields: make([]Field, 1, 4)}
+	e.Fields[0] = Field{"a", IntValue(1)}
+	first := string(enc.AppendEntry(nil, &e))
+	second := string(enc.AppendEntry(nil, &e))
+
+	if want := `{"mesg":"hello","a":1,"svc":"api"}` + "\n"; first != want || second != want {
+		t.Fatal(first, second)
+	} else if len(e.Fields) != 1 || e.Fields[:2][1].Key != "" {
+		t.Fatal(e.Fields[:2])
+	}
+}
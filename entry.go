@@ -0,0 +1,365 @@
+package slog
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ErrMalformed is returned by Parse if the log line does not match the prefix and flags.
+var ErrMalformed = errors.New("slog: malformed log line")
+
+// Field is a key-value pair parsed from a log message.
+type Field struct {
+	Key   string
+	Value Value
+}
+
+// Entry is a parsed log line.
+type Entry struct {
+	// Time is the time stamp of the entry. The date is zero if log.Ldate is not set.
+	Time time.Time
+	// Prefix is the prefix if log.Lmsgprefix is not set. The components of
+	// a chained prefix such as "api: db: " are joined with dots, as in "api.db".
+	Prefix string
+	// File and Line are the file name and line number if log.Llongfile or log.Lshortfile is set.
+	File string
+	Line int
+	// Func is the name of the calling function if Lfuncname is set.
+	// It is never set by Parse.
+	Func string
+	// Message is the log message.
+	Message string
+	// Fields are the key-value pairs found in the message if Lparsefields is set.
+	Fields []Field
+	// Raw is the original log line without the trailing newline.
+	Raw string
+}
+
+// Get returns the value of the first field with the given key.
+func (e *Entry) Get(key string) (Value, bool) {
+	for _, f := range e.Fields {
+		if f.Key == key {
+			return f.Value, true
+		}
+	}
+	return Value{}, false
+}
+
+// freeText returns the text of a message that is not part of a key-value pair.
+// Fragments are separated by single spaces.
+func freeText(s string) string {
+	var b strings.Builder
+	for len(s) > 0 {
+		start := trimLeftSpace(s)
+		var ok bool
+		if s, _, _, _, ok = scanKeyVals(start); !ok {
+			if frag := strings.TrimSpace(start[:len(start)-len(s)]); frag != "" {
+				if b.Len() > 0 {
+					b.WriteByte(' ')
+				}
+				b.WriteString(frag)
+			}
+		}
+	}
+	return b.String()
+}
+
+// PrefixPath returns the components of the prefix.
+func (e *Entry) PrefixPath() []string {
+	if e.Prefix == "" {
+		return nil
+	}
+	return strings.Split(e.Prefix, ".")
+}
+
+// prefixPath trims the components of a prefix chained by Named of spaces and
+// punctuation marks and joins them with dots.
+func prefixPath(prefix string) string {
+	prefix = strings.TrimFunc(prefix, isSpaceOrPunct)
+	if !strings.Contains(prefix, ": ") {
+		return prefix
+	}
+
+	var path []string
+	for _, s := range strings.Split(prefix, ": ") {
+		if s = strings.TrimFunc(s, isSpaceOrPunct); s != "" {
+			path = append(path, s)
+		}
+	}
+	return strings.Join(path, ".")
+}
+
+// Set sets the value of the first field with the given key,
+// or appends a new field if there is none.
+func (e *Entry) Set(key string, val Value) {
+	for i := range e.Fields {
+		if e.Fields[i].Key == key {
+			e.Fields[i].Value = val
+			return
+		}
+	}
+	e.Fields = append(e.Fields, Field{key, val})
+}
+
+// insert inserts a field at index i.
+func (e *Entry) insert(i int, f Field) {
+	e.Fields = append(e.Fields, Field{})
+	copy(e.Fields[i+1:], e.Fields[i:])
+	e.Fields[i] = f
+}
+
+// Delete removes all fields with the given key.
+func (e *Entry) Delete(key string) {
+	fields := e.Fields[:0]
+	for _, f := range e.Fields {
+		if f.Key != key {
+			fields = append(fields, f)
+		}
+	}
+	e.Fields = fields
+}
+
+// Rename renames all fields with the key from to the key to.
+func (e *Entry) Rename(from, to string) {
+	for i := range e.Fields {
+		if e.Fields[i].Key == from {
+			e.Fields[i].Key = to
+		}
+	}
+}
+
+func atoiFixed(s string) (n int, ok bool) {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, false
+		}
+		n = n*10 + int(s[i]-'0')
+	}
+	return n, true
+}
+
+// trimStdSep trims the space that follows a component of the time stamp.
+// The space is missing at the end of a line with an empty message,
+// because trailing white space is trimmed.
+func trimStdSep(s string) (string, bool) {
+	if s == "" {
+		return s, true
+	} else if s[0] != ' ' {
+		return "", false
+	}
+	return s[1:], true
+}
+
+// parseStdTime parses the time stamp produced by the standard logger
+// and returns the remaining text.
+func parseStdTime(s string, flags int) (t time.Time, z string, ok bool) {
+	var year, month, day, hour, min, sec, usec int
+	year, month, day = 0, 1, 1
+
+	if flags&log.Ldate != 0 {
+		// 2006/01/02
+		if len(s) < 10 || s[4] != '/' || s[7] != '/' {
+			return
+		}
+		y, ok1 := atoiFixed(s[0:4])
+		m, ok2 := atoiFixed(s[5:7])
+		d, ok3 := atoiFixed(s[8:10])
+		if !ok1 || !ok2 || !ok3 {
+			return
+		}
+		var sepOK bool
+		if s, sepOK = trimStdSep(s[10:]); !sepOK {
+			return
+		}
+		year, month, day = y, m, d
+	}
+
+	if flags&(log.Ltime|log.Lmicroseconds) != 0 {
+		// 15:04:05
+		if len(s) < 8 || s[2] != ':' || s[5] != ':' {
+			return
+		}
+		h, ok1 := atoiFixed(s[0:2])
+		m, ok2 := atoiFixed(s[3:5])
+		c, ok3 := atoiFixed(s[6:8])
+		if !ok1 || !ok2 || !ok3 {
+			return
+		}
+		hour, min, sec, s = h, m, c, s[8:]
+
+		// .000000
+		if flags&log.Lmicroseconds != 0 {
+			if len(s) < 7 || s[0] != '.' {
+				return
+			}
+			if usec, ok = atoiFixed(s[1:7]); !ok {
+				return
+			}
+			s = s[7:]
+		}
+
+		if s, ok = trimStdSep(s); !ok {
+			return time.Time{}, "", false
+		}
+	}
+
+	loc := time.Local
+	if flags&log.LUTC != 0 {
+		loc = time.UTC
+	}
+
+	return time.Date(year, time.Month(month), day, hour, min, sec, usec*1000, loc), s, true
+}
+
+func appendDigits(dst []byte, n, width int) []byte {
+	var buf [8]byte
+	for i := width - 1; i >= 0; i-- {
+		buf[i] = byte('0' + n%10)
+		n /= 10
+	}
+	return append(dst, buf[:width]...)
+}
+
+// appendTime appends the time stamp in RFC3339 format,
+// omitting the parts that are not enabled by the flags.
+func appendTime(dst []byte, t time.Time, flags int) []byte {
+	hasDate := flags&log.Ldate != 0
+	hasTime := flags&(log.Ltime|log.Lmicroseconds) != 0
+
+	if hasDate {
+		year, month, day := t.Date()
+		dst = appendDigits(dst, year, 4)
+		dst = append(dst, '-')
+		dst = appendDigits(dst, int(month), 2)
+		dst = append(dst, '-')
+		dst = appendDigits(dst, day, 2)
+		if hasTime {
+			dst = append(dst, 'T')
+		}
+	}
+
+	if hasTime {
+		hour, min, sec := t.Clock()
+		dst = appendDigits(dst, hour, 2)
+		dst = append(dst, ':')
+		dst = appendDigits(dst, min, 2)
+		dst = append(dst, ':')
+		dst = appendDigits(dst, sec, 2)
+		if flags&log.Lmicroseconds != 0 {
+			dst = append(dst, '.')
+			dst = appendDigits(dst, t.Nanosecond()/1000, 6)
+		}
+	}
+
+	if hasDate && hasTime && flags&log.LUTC != 0 {
+		dst = append(dst, 'Z')
+	}
+
+	return dst
+}
+
+// fileLine finds the colons around the line number in text that starts with file:line:.
+// The file name may contain colons itself, such as the drive letter of a Windows path
+// like C:/src/main.go:42:, so the first colon that is followed by digits and a colon is used.
+func fileLine(text string) (i, j int) {
+	for i = 0; i < len(text); i++ {
+		if text[i] != ':' {
+			continue
+		}
+		j = i + 1
+		for j < len(text) && text[j] >= '0' && text[j] <= '9' {
+			j++
+		}
+		if j > i+1 && j < len(text) && text[j] == ':' {
+			return i, j
+		}
+	}
+	return -1, -1
+}
+
+// parseEntry parses text into e, reusing the fields slice of e.
+// The strings of e alias text.
+func parseEntry(e *Entry, text, prefix string, flags int) error {
+	fields := e.Fields[:0]
+	*e = Entry{}
+
+	text = strings.TrimRightFunc(text, unicode.IsSpace)
+	e.Raw = text
+
+	// prefix
+	if prefix != "" && flags&log.Lmsgprefix == 0 {
+		if !strings.HasPrefix(text, prefix) {
+			return ErrMalformed
+		}
+		text = text[len(prefix):]
+		e.Prefix = prefixPath(prefix)
+	}
+
+	// date and time
+	if flags&(log.Ldate|log.Ltime|log.Lmicroseconds) != 0 {
+		var ok bool
+		if e.Time, text, ok = parseStdTime(text, flags); !ok {
+			return ErrMalformed
+		}
+	}
+
+	// file name and line number
+	if flags&(log.Llongfile|log.Lshortfile) != 0 {
+		i, j := fileLine(text)
+		if i == -1 {
+			return ErrMalformed
+		}
+		line, err := strconv.Atoi(text[i+1 : j])
+		if err != nil {
+			return ErrMalformed
+		}
+		e.File, e.Line, text = text[:i], line, strings.TrimPrefix(text[j+1:], " ")
+	}
+
+	// message
+	e.Message = text
+
+	// fields, excluding the prefix that is part of the message
+	if prefix != "" && flags&log.Lmsgprefix != 0 {
+		text = strings.TrimPrefix(text, strings.TrimRightFunc(prefix, unicode.IsSpace))
+	}
+	if flags&Lparsefields != 0 {
+		fields = appendFields(fields, text)
+	}
+	e.Fields = fields
+
+	return nil
+}
+
+// appendFields appends the key-value fields found in text.
+func appendFields(fields []Field, text string) []Field {
+	if strings.IndexByte(text, '=') == -1 {
+		return fields
+	}
+	for len(text) > 0 {
+		var key, val string
+		var quote, ok bool
+		text, key, val, quote, ok = scanKeyVals(text)
+		if ok {
+			fields = append(fields, Field{key, parseValue(val, quote)})
+			if key == "traceparent" {
+				fields = appendTraceparent(fields, val)
+			}
+		}
+	}
+	return fields
+}
+
+// Parse parses a log line produced by a standard logger with the given prefix and flags.
+// The message is parsed for key-value fields if Lparsefields is set.
+func Parse(line, prefix string, flags int) (Entry, error) {
+	var e Entry
+	if err := parseEntry(&e, line, prefix, flags); err != nil {
+		return Entry{}, err
+	}
+	return e, nil
+}
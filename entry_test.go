@@ -0,0 +1,188 @@
+package slog
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseEntry(t *testing.T) {
+	line := "app: 2021/08/08 19:06:35.252044 main.go:42: hello a=1 b=\"x y\" c=2.5 d=true e=<nil>\n"
+	e, err := Parse(line, "app: ", log.LstdFlags|log.Lmicroseconds|log.LUTC|log.Lshortfile|Lparsefields)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Prefix != "app" {
+		t.Fatal(e.Prefix)
+	} else if !e.Time.Equal(time.Date(2021, 8, 8, 19, 6, 35, 252044000, time.UTC)) {
+		t.Fatal(e.Time)
+	} else if e.File != "main.go" || e.Line != 42 {
+		t.Fatal(e.File, e.Line)
+	} else if e.Message != "hello a=1 b=\"x y\" c=2.5 d=true e=<nil>" {
+		t.Fatal(e.Message)
+	} else if len(e.Fields) != 5 {
+		t.Fatal(e.Fields)
+	}
+
+	if v, _ := e.Get("a"); v.Kind() != KindInt || v.Int() != 1 {
+		t.Fatal(v)
+	} else if v, _ := e.Get("b"); v.Kind() != KindString || v.String() != "x y" {
+		t.Fatal(v)
+	} else if v, _ := e.Get("c"); v.Kind() != KindFloat || v.Float() != 2.5 {
+		t.Fatal(v)
+	} else if v, _ := e.Get("d"); !v.Bool() {
+		t.Fatal(v)
+	} else if v, _ := e.Get("e"); v.Kind() != KindNull || v.Any() != nil {
+		t.Fatal(v)
+	} else if _, ok := e.Get("f"); ok {
+		t.Fatal()
+	}
+}
+
+func TestParseWindowsPath(t *testing.T) {
+	for _, file := range []string{`C:/src/app/main.go`, `C:\src\app\main.go`} {
+		e, err := Parse(file+":42: took 10:30: a=1", "", log.Llongfile|Lparsefields)
+		if err != nil {
+			t.Fatal(err)
+		} else if e.File != file || e.Line != 42 {
+			t.Fatal(e.File, e.Line)
+		} else if e.Message != "took 10:30: a=1" {
+			t.Fatal(e.Message)
+		}
+	}
+}
+
+func TestParsePrefixWithFields(t *testing.T) {
+	for _, line := range []string{"[job=sync] a=1", "[job=sync]"} {
+		e, err := Parse(line, "[job=sync] ", log.Lmsgprefix|Lparsefields)
+		if err != nil {
+			t.Fatal(err)
+		} else if e.Message != line {
+			t.Fatal(e.Message)
+		} else if _, ok := e.Get("[job"); ok {
+			t.Fatal(e.Fields)
+		}
+	}
+
+	var b bytes.Buffer
+	l := New(&b, "[job=sync id=7] ", log.Lmsgprefix|Lparsefields, PrefixFields())
+	l.Print("a=1")
+	if want := `{"a":1,"job":"sync","id":7}` + "\n"; b.String() != want {
+		t.Fatal(b.String())
+	}
+}
+
+func TestParseMalformed(t *testing.T) {
+	for _, testCase := range []struct {
+		Line  string
+		Flags int
+	}{
+		{"hello", log.Ldate},
+		{"2021/08/08 hello", log.LstdFlags},
+		{"2021/08/08 19:06:35 hello", log.LstdFlags | log.Lmicroseconds},
+		{"main.go hello", log.Lshortfile},
+		{"main.go:x: hello", log.Lshortfile},
+	} {
+		if _, err := Parse(testCase.Line, "", testCase.Flags); err != ErrMalformed {
+			t.Fatal(testCase.Line, err)
+		}
+	}
+
+	if _, err := Parse("hello", "app: ", 0); err != ErrMalformed {
+		t.Fatal(err)
+	}
+}
+
+func TestTimeOnly(t *testing.T) {
+	e, err := Parse("19:06:35 hello", "", log.Ltime)
+	if err != nil {
+		t.Fatal(err)
+	} else if b := appendTime(nil, e.Time, log.Ltime); string(b) != "19:06:35" {
+		t.Fatal(string(b))
+	} else if e.Message != "hello" {
+		t.Fatal(e.Message)
+	}
+}
+
+func TestParseEmptyMessage(t *testing.T) {
+	for _, testCase := range []struct {
+		Line  string
+		Flags int
+		Time  time.Time
+	}{
+		{"2021/08/08 19:06:35\n", log.LstdFlags, time.Date(2021, 8, 8, 19, 6, 35, 0, time.UTC)},
+		{"2021/08/08 19:06:35.252044\n", log.LstdFlags | log.Lmicroseconds, time.Date(2021, 8, 8, 19, 6, 35, 252044000, time.UTC)},
+		{"2021/08/08\n", log.Ldate, time.Date(2021, 8, 8, 0, 0, 0, 0, time.UTC)},
+	} {
+		e, err := Parse(testCase.Line, "", testCase.Flags|log.LUTC)
+		if err != nil {
+			t.Fatal(testCase.Line, err)
+		} else if !e.Time.Equal(testCase.Time) || e.Message != "" {
+			t.Fatal(testCase.Line, e.Time, e.Message)
+		}
+	}
+
+	var b bytes.Buffer
+	New(&b, "", log.LstdFlags|log.LUTC|Lmessage).Println("")
+	if !bytes.HasPrefix(b.Bytes(), []byte(`{"time":"`)) || !bytes.HasSuffix(b.Bytes(), []byte(`"mesg":""}`+"\n")) {
+		t.Fatal(b.String())
+	}
+}
+
+func TestPrefixPath(t *testing.T) {
+	for _, testCase := range []struct {
+		Prefix string
+		Exp    string
+	}{
+		{"app: ", "app"},
+		{"my-app: ", "my-app"},
+		{"api: db: ", "api.db"},
+		{"my app: ", "my app"},
+		{"my app: db: ", "my app.db"},
+		{"v1.2 ", "v1.2"},
+		{"api: : db: ", "api.db"},
+	} {
+		e, err := Parse(testCase.Prefix+"hello", testCase.Prefix, 0)
+		if err != nil {
+			t.Fatal(err)
+		} else if e.Prefix != testCase.Exp {
+			t.Fatal(testCase.Prefix, e.Prefix)
+		}
+	}
+
+	e := Entry{Prefix: "api.db"}
+	if p := e.PrefixPath(); len(p) != 2 || p[0] != "api" || p[1] != "db" {
+		t.Fatal(p)
+	}
+}
+
+func TestLraw(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "app: ", log.Ltime|Lraw|Lparsefields)
+	l.Println("hello a=1")
+
+	var e Entry
+	if err := NewDecoder(&b).Decode(&e); err != nil {
+		t.Fatal(err)
+	} else if !strings.HasPrefix(e.Raw, "app: ") || !strings.HasSuffix(e.Raw, " hello a=1") {
+		t.Fatal(e.Raw)
+	}
+}
+
+func TestFreeText(t *testing.T) {
+	for _, testCase := range []struct {
+		Str string
+		Exp string
+	}{
+		{"a=1 b=2", ""},
+		{"request failed code=500", "request failed"},
+		{"  x=\"a b\"  hello   world y=\"z", "hello world y=\"z"},
+	} {
+		if s := freeText(testCase.Str); s != testCase.Exp {
+			t.Fatal(testCase.Str, s)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package slog
+
+import (
+	"bytes"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// quoteFunc appends the quoted JSON string s to dst.
+type quoteFunc func(dst []byte, s string) []byte
+
+const hexDigits = "0123456789abcdef"
+
+func appendEscape(dst []byte, r rune) []byte {
+	return append(dst, '\\', 'u',
+		hexDigits[r>>12&0xf], hexDigits[r>>8&0xf], hexDigits[r>>4&0xf], hexDigits[r&0xf])
+}
+
+// appendQuoteASCII is like strconv.AppendQuote, but escapes all non-ASCII runes
+// as \uXXXX, using surrogate pairs for runes outside of the Basic Multilingual Plane,
+// so that the output is valid JSON. Invalid UTF-8 is replaced by �.
+func appendQuoteASCII(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	for len(s) > 0 {
+		i := 0
+		for i < len(s) && s[i] < utf8.RuneSelf {
+			i++
+		}
+		if i > 0 {
+			// quote the ASCII run and strip the quotes
+			n := len(dst)
+			dst = strconv.AppendQuote(dst, s[:i])
+			dst = append(dst[:n], dst[n+1:len(dst)-1]...)
+			s = s[i:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s)
+		if r > 0xffff {
+			r1, r2 := utf16.EncodeRune(r)
+			dst = appendEscape(appendEscape(dst, r1), r2)
+		} else {
+			dst = appendEscape(dst, r)
+		}
+		s = s[size:]
+	}
+	return append(dst, '"')
+}
+
+// ASCIIOnly escapes all non-ASCII runes in the keys and string values as \uXXXX,
+// for pipelines and legacy systems that mishandle raw UTF-8.
+func ASCIIOnly() Option {
+	return func(l *logwriter) {
+		l.asciiOnly = true
+	}
+}
+
+// escapeHTML wraps a quote function to escape <, > and & as \u003c, \u003e and \u0026.
+// The escapes produced by quote never contain these characters.
+func escapeHTML(quote quoteFunc) quoteFunc {
+	return func(dst []byte, s string) []byte {
+		n := len(dst)
+		dst = quote(dst, s)
+		if bytes.IndexAny(dst[n:], "<>&") == -1 {
+			return dst
+		}
+		quoted := append([]byte(nil), dst[n:]...)
+		dst = dst[:n]
+		for _, c := range quoted {
+			if c == '<' || c == '>' || c == '&' {
+				dst = appendEscape(dst, rune(c))
+			} else {
+				dst = append(dst, c)
+			}
+		}
+		return dst
+	}
+}
+
+// HTMLSafe escapes <, > and & in the keys and string values as \u003c, \u003e
+// and \u0026 like encoding/json does, so that entries can be embedded in HTML.
+func HTMLSafe() Option {
+	return func(l *logwriter) {
+		l.htmlSafe = true
+	}
+}
+
+// quoter returns the quote function selected by the options.
+func (l *logwriter) quoter() quoteFunc {
+	quote := strconv.AppendQuote
+	if l.asciiOnly {
+		quote = appendQuoteASCII
+	}
+	if l.htmlSafe {
+		return escapeHTML(quote)
+	}
+	return quote
+}
@@ -0,0 +1,34 @@
+package slog
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestASCIIOnly(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields, ASCIIOnly())
+	l.Print("naïve=\"café 😀\" tab=\"a\tb\" bad=\"\xff\"")
+
+	want := `{"na\u00efve":"caf\u00e9 \ud83d\ude00","tab":"a\tb","bad":"\ufffd"}` + "\n"
+	if b.String() != want {
+		t.Fatal(b.String())
+	}
+
+	var m map[string]string
+	if err := json.Unmarshal(b.Bytes(), &m); err != nil || m["naïve"] != "café 😀" {
+		t.Fatal(m, err)
+	}
+}
+
+func TestHTMLSafe(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields, HTMLSafe(), ASCIIOnly())
+	l.Print("html=\"<b>Tom & Jérôme</b>\"")
+
+	want := `{"html":"\u003cb\u003eTom \u0026 J\u00e9r\u00f4me\u003c/b\u003e"}` + "\n"
+	if b.String() != want {
+		t.Fatal(b.String())
+	}
+}
@@ -0,0 +1,41 @@
+package slog
+
+// Scanner scans a string for key-value pairs using the same rules as Lparsefields.
+// Text that is not part of a key-value pair is skipped.
+//
+//	s := slog.NewScanner(`user=alice tries=3 "not a field" msg="a b"`)
+//	for s.Scan() {
+//		fmt.Println(s.Key(), s.Value())
+//	}
+type Scanner struct {
+	s     string
+	key   string
+	value Value
+}
+
+// NewScanner returns a scanner that reads from s.
+func NewScanner(s string) *Scanner {
+	return &Scanner{s: s}
+}
+
+// Scan advances the scanner to the next key-value pair.
+// It returns false when there are no more pairs.
+func (s *Scanner) Scan() bool {
+	for len(s.s) > 0 {
+		var key, val string
+		var quote, ok bool
+		if s.s, key, val, quote, ok = scanKeyVals(s.s); ok {
+			s.key, s.value = key, parseValue(val, quote)
+			return true
+		}
+	}
+	s.key, s.value = "", Value{}
+	return false
+}
+
+// Key returns the key of the most recent pair found by Scan.
+func (s *Scanner) Key() string { return s.key }
+
+// Value returns the value of the most recent pair found by Scan.
+// Its type is inferred like the values of Lparsefields.
+func (s *Scanner) Value() Value { return s.value }
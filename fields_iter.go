@@ -0,0 +1,19 @@
+//go:build go1.23
+// +build go1.23
+
+package slog
+
+import "iter"
+
+// Fields returns an iterator over the key-value pairs of s.
+// It uses the same parsing rules as Lparsefields and Scanner.
+func Fields(s string) iter.Seq2[string, Value] {
+	return func(yield func(string, Value) bool) {
+		sc := NewScanner(s)
+		for sc.Scan() {
+			if !yield(sc.Key(), sc.Value()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,17 @@
+//go:build go1.23
+// +build go1.23
+
+package slog
+
+import "testing"
+
+func TestFields(t *testing.T) {
+	var keys []string
+	Fields("a=1 b=2 c=3")(func(key string, _ Value) bool {
+		keys = append(keys, key)
+		return key != "b"
+	})
+	if len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+		t.Fatal(keys)
+	}
+}
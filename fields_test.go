@@ -0,0 +1,21 @@
+package slog
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestScanner(t *testing.T) {
+	var b strings.Builder
+	s := NewScanner(`user=alice tries=3 not a field msg="a b" ok=true`)
+	for s.Scan() {
+		fmt.Fprintf(&b, "%s:%v:%d ", s.Key(), s.Value().Any(), s.Value().Kind())
+	}
+	if want := "user:alice:0 tries:3:1 msg:a b:0 ok:true:3 "; b.String() != want {
+		t.Fatal(b.String())
+	}
+	if s.Scan() || s.Key() != "" {
+		t.Fatal("scan after end")
+	}
+}
@@ -0,0 +1,157 @@
+package slog
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileOptions configures the durability of a FileWriter.
+// The zero value never calls fsync and leaves flushing to the operating system.
+type FileOptions struct {
+	// SyncEvery syncs the file after every n entries.
+	SyncEvery int
+
+	// SyncInterval syncs the file periodically if entries were written since the last sync.
+	SyncInterval time.Duration
+
+	// SyncLevel syncs the file after every entry at or above the level,
+	// so that errors survive a crash of the machine.
+	SyncLevel Level
+
+	// Perm are the permissions of a newly created file. Defaults to 0644.
+	Perm os.FileMode
+}
+
+// FileWriter appends entries to a file with a selectable durability policy.
+// It is safe for concurrent use.
+type FileWriter struct {
+	mu        sync.Mutex
+	f         *os.File
+	opts      FileOptions
+	pending   int
+	err       error
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// OpenFile opens the named file for appending, creating it if necessary.
+func OpenFile(name string, opts FileOptions) (*FileWriter, error) {
+	if opts.Perm == 0 {
+		opts.Perm = 0644
+	}
+
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND|os.O_CREATE, opts.Perm)
+	if err != nil {
+		return nil, err
+	}
+
+	fw := &FileWriter{f: f, opts: opts, done: make(chan struct{})}
+	if opts.SyncInterval > 0 {
+		fw.wg.Add(1)
+		go fw.loop()
+	}
+
+	return fw, nil
+}
+
+func (fw *FileWriter) loop() {
+	defer fw.wg.Done()
+	t := time.NewTicker(fw.opts.SyncInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			fw.mu.Lock()
+			if err := fw.syncLocked(); err != nil && fw.err == nil {
+				fw.err = err
+			}
+			fw.mu.Unlock()
+		case <-fw.done:
+			return
+		}
+	}
+}
+
+func (fw *FileWriter) syncLocked() error {
+	if fw.pending == 0 {
+		return nil
+	}
+	fw.pending = 0
+	return fw.f.Sync()
+}
+
+// Write appends an entry to the file and syncs it according to the policy.
+func (fw *FileWriter) Write(p []byte) (int, error) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	if err := fw.err; err != nil {
+		fw.err = nil
+		return 0, err
+	}
+
+	n, err := fw.f.Write(p)
+	if err != nil {
+		return n, err
+	}
+
+	if fw.syncDue(p) {
+		if err := fw.syncLocked(); err != nil {
+			return n, err
+		}
+	}
+
+	return n, nil
+}
+
+// syncDue counts the entries in p, which may hold several lines if the
+// writer is batched, and reports whether the policy requires a sync.
+func (fw *FileWriter) syncDue(p []byte) bool {
+	var due bool
+	for len(p) > 0 {
+		line := p
+		if i := bytes.IndexByte(p, '\n'); i >= 0 {
+			line, p = p[:i+1], p[i+1:]
+		} else {
+			p = nil
+		}
+		fw.pending++
+		if !due && fw.opts.SyncLevel != 0 {
+			level, ok := lineLevel(line)
+			due = ok && level >= fw.opts.SyncLevel
+		}
+	}
+	return due || fw.opts.SyncEvery > 0 && fw.pending >= fw.opts.SyncEvery
+}
+
+// Sync commits the written entries to stable storage.
+func (fw *FileWriter) Sync() error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	fw.pending = 1
+	return fw.syncLocked()
+}
+
+// Close syncs and closes the file.
+func (fw *FileWriter) Close() error {
+	fw.closeOnce.Do(func() { close(fw.done) })
+	fw.wg.Wait()
+
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	err := fw.f.Sync()
+	if cerr := fw.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Drain is like Close, but gives up waiting for the file to be synced
+// when the context is done.
+func (fw *FileWriter) Drain(ctx context.Context) error {
+	return drainFunc(ctx, fw.Close)
+}
@@ -0,0 +1,56 @@
+package slog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileWriter(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "app.log")
+	fw, err := OpenFile(name, FileOptions{SyncEvery: 2, SyncLevel: LevelError})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := New(fw, "", Lparsefields)
+	l.Println("level=info a=1")
+	if fw.pending != 1 {
+		t.Fatal(fw.pending)
+	}
+
+	l.Println("level=error a=2")
+	if fw.pending != 0 {
+		t.Fatal(fw.pending)
+	}
+
+	l.Println("level=info a=3")
+	l.Println("level=info a=4")
+	if fw.pending != 0 {
+		t.Fatal(fw.pending)
+	}
+
+	// a batch of several entries is synced if any of them qualifies
+	_, _ = fw.Write([]byte("{\"level\":\"info\",\"a\":5}\n{\"level\":\"error\",\"a\":6}\n"))
+	if fw.pending != 0 {
+		t.Fatal(fw.pending)
+	}
+	fw.opts.SyncLevel = 0
+	_, _ = fw.Write([]byte("{\"a\":7}\n"))
+	_, _ = fw.Write([]byte("{\"a\":8}\n{\"a\":9}\n{\"a\":10}\n"))
+	if fw.pending != 0 {
+		t.Fatal(fw.pending)
+	}
+
+	if err := fw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	} else if exp := "{\"level\":\"info\",\"a\":1}\n{\"level\":\"error\",\"a\":2}\n{\"level\":\"info\",\"a\":3}\n{\"level\":\"info\",\"a\":4}\n" +
+		"{\"level\":\"info\",\"a\":5}\n{\"level\":\"error\",\"a\":6}\n{\"a\":7}\n{\"a\":8}\n{\"a\":9}\n{\"a\":10}\n"; string(b) != exp {
+		t.Fatal(string(b))
+	}
+}
@@ -0,0 +1,55 @@
+package slog
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Limits of the Firehose PutRecordBatch operation.
+const (
+	firehoseMaxRecords    = 500
+	firehoseMaxBytes      = 4 << 20
+	firehoseMaxRecordSize = 1000 << 10
+)
+
+// FirehoseClient puts a batch of records to a Kinesis Data Firehose delivery stream.
+// It is implemented by a thin wrapper around the PutRecordBatch operation of the AWS SDK,
+// so that slog does not depend on it.
+type FirehoseClient interface {
+	PutRecordBatch(ctx context.Context, stream string, records [][]byte) error
+}
+
+// FirehoseOptions configures a Firehose writer.
+type FirehoseOptions struct {
+	// Interval is the maximum time that entries are buffered.
+	// Defaults to five seconds.
+	Interval time.Duration
+
+	// Timeout limits the duration of each PutRecordBatch call.
+	// Defaults to thirty seconds.
+	Timeout time.Duration
+}
+
+// NewFirehoseWriter creates a writer that batches entries into PutRecordBatch calls
+// to the Firehose delivery stream. A batch is sent when it reaches 500 records or 4 MiB,
+// or when the interval elapses. Entries larger than 1000 KiB are rejected with
+// ErrRecordTooLarge. Failed batches are sent again at the next flush.
+// Errors of asynchronous sends are returned by the next call to Write or Close.
+// Close must be called to send the last batch.
+func NewFirehoseWriter(client FirehoseClient, stream string, opts FirehoseOptions) io.WriteCloser {
+	if opts.Interval <= 0 {
+		opts.Interval = 5 * time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+
+	put := func(recs [][]byte) error {
+		ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+		defer cancel()
+		return client.PutRecordBatch(ctx, stream, recs)
+	}
+
+	return newBatcher(firehoseMaxRecords, firehoseMaxBytes, firehoseMaxRecordSize, opts.Interval, put)
+}
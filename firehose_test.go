@@ -0,0 +1,87 @@
+package slog
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type firehoseRecorder struct {
+	stream  string
+	batches [][][]byte
+}
+
+func (f *firehoseRecorder) PutRecordBatch(_ context.Context, stream string, recs [][]byte) error {
+	f.stream = stream
+	f.batches = append(f.batches, recs)
+	return nil
+}
+
+func TestFirehoseWriter(t *testing.T) {
+	var rec firehoseRecorder
+	w := NewFirehoseWriter(&rec, "logs", FirehoseOptions{})
+	l := New(w, "", Lmessage)
+	for i := 0; i < firehoseMaxRecords+1; i++ {
+		l.Println("hello")
+	}
+
+	if len(rec.batches) != 1 || len(rec.batches[0]) != firehoseMaxRecords {
+		t.Fatal(len(rec.batches))
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	} else if len(rec.batches) != 2 || len(rec.batches[1]) != 1 {
+		t.Fatal(len(rec.batches))
+	} else if rec.stream != "logs" || string(rec.batches[1][0]) != "{\"mesg\":\"hello\"}\n" {
+		t.Fatal(rec.stream, string(rec.batches[1][0]))
+	}
+}
+
+type failingFirehose struct {
+	firehoseRecorder
+	fail int
+}
+
+func (f *failingFirehose) PutRecordBatch(ctx context.Context, stream string, recs [][]byte) error {
+	if f.fail > 0 {
+		f.fail--
+		return errors.New("unavailable")
+	}
+	return f.firehoseRecorder.PutRecordBatch(ctx, stream, recs)
+}
+
+func TestFirehoseWriterRetry(t *testing.T) {
+	client := failingFirehose{fail: 2}
+	w := NewFirehoseWriter(&client, "logs", FirehoseOptions{}).(*batcher)
+	_, _ = w.Write([]byte("{\"a\":1}\n"))
+
+	if err := w.Flush(); err == nil || err.Error() != "unavailable" {
+		t.Fatal(err)
+	}
+	_, _ = w.Write([]byte("{\"a\":2}\n"))
+	if err := w.Flush(); err == nil || err.Error() != "unavailable" {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	} else if len(client.batches) != 2 || string(client.batches[0][0]) != "{\"a\":1}\n" || string(client.batches[1][0]) != "{\"a\":2}\n" {
+		t.Fatal(client.batches)
+	}
+
+	if _, err := w.Write(make([]byte, firehoseMaxRecordSize+1)); err != ErrRecordTooLarge {
+		t.Fatal(err)
+	}
+}
+
+func TestBatcherErrors(t *testing.T) {
+	b := newBatcher(1, 1<<10, 1<<10, 0, func([][]byte) error { return errors.New("down") })
+	for i := 0; i < maxAttempts; i++ {
+		_, _ = b.Write([]byte("x"))
+	}
+	b.err = errors.New("async")
+	err := b.Flush()
+	if err == nil || err.Error() != "async; slog: 1 entries dropped after failed sends; down" {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,86 @@
+package slog
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// flagNames are the names of the flags in the order of their bits.
+var flagNames = []struct {
+	name string
+	flag int
+}{
+	{"date", log.Ldate},
+	{"time", log.Ltime},
+	{"microseconds", log.Lmicroseconds},
+	{"longfile", log.Llongfile},
+	{"shortfile", log.Lshortfile},
+	{"utc", log.LUTC},
+	{"msgprefix", log.Lmsgprefix},
+	{"color", Lcolor},
+	{"parsefields", Lparsefields},
+	{"message", Lmessage},
+	{"priority", Lpriority},
+	{"funcname", Lfuncname},
+	{"raw", Lraw},
+	{"timestamp", Ltimestamp},
+}
+
+// ParseFlags parses flags written as names separated by vertical bars,
+// such as "date|time|utc|msgprefix|color|parsefields". The names are those of
+// the flag constants without the L prefix in lower case. The name stdflags stands
+// for LstdFlags and decimal numbers are accepted as well, so that ParseFlags
+// accepts the output of FormatFlags. The empty string means no flags.
+func ParseFlags(s string) (int, error) {
+	var flags int
+	if strings.TrimSpace(s) == "" {
+		return 0, nil
+	}
+	for _, name := range strings.Split(s, "|") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "stdflags" {
+			flags |= LstdFlags
+			continue
+		}
+		if n, err := strconv.Atoi(name); err == nil && n >= 0 {
+			flags |= n
+			continue
+		}
+		found := false
+		for _, f := range flagNames {
+			if f.name == name {
+				flags |= f.flag
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, errors.New("slog: unknown flag " + strconv.Quote(name))
+		}
+	}
+	return flags, nil
+}
+
+// FormatFlags returns the names of the flags separated by vertical bars.
+// It is the inverse of ParseFlags. Unknown bits are formatted as a decimal number.
+func FormatFlags(flags int) string {
+	var b strings.Builder
+	for _, f := range flagNames {
+		if flags&f.flag != 0 {
+			if b.Len() > 0 {
+				b.WriteByte('|')
+			}
+			b.WriteString(f.name)
+			flags &^= f.flag
+		}
+	}
+	if flags != 0 {
+		if b.Len() > 0 {
+			b.WriteByte('|')
+		}
+		b.WriteString(strconv.Itoa(flags))
+	}
+	return b.String()
+}
@@ -0,0 +1,40 @@
+package slog
+
+import (
+	"log"
+	"testing"
+)
+
+func TestParseFlags(t *testing.T) {
+	for _, tc := range []struct {
+		s     string
+		flags int
+	}{
+		{"", 0},
+		{"date|time|utc|msgprefix|color|parsefields", log.Ldate | log.Ltime | log.LUTC | log.Lmsgprefix | Lcolor | Lparsefields},
+		{" Date | shortfile ", log.Ldate | log.Lshortfile},
+		{"stdflags|raw", LstdFlags | Lraw},
+		{"message|1073741824", Lmessage | 1<<30},
+	} {
+		flags, err := ParseFlags(tc.s)
+		if err != nil || flags != tc.flags {
+			t.Fatal(tc.s, flags, err)
+		}
+		if back, err := ParseFlags(FormatFlags(flags)); err != nil || back != flags {
+			t.Fatal(tc.s, FormatFlags(flags), err)
+		}
+	}
+
+	if _, err := ParseFlags("date|colour"); err == nil {
+		t.Fatal("unknown flag accepted")
+	}
+}
+
+func TestFormatFlags(t *testing.T) {
+	if s := FormatFlags(log.LstdFlags | Lparsefields); s != "date|time|parsefields" {
+		t.Fatal(s)
+	}
+	if s := FormatFlags(0); s != "" {
+		t.Fatal(s)
+	}
+}
@@ -0,0 +1,106 @@
+package slog
+
+import (
+	"log"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var glogLevels = map[byte]Level{
+	'I': LevelInfo,
+	'W': LevelWarn,
+	'E': LevelError,
+	'F': LevelFatal,
+}
+
+// GlogInput parses log lines in the format of glog and klog, which is used by
+// Kubernetes components and other programs built on glog:
+//
+//	I0601 12:00:00.123456   12345 server.go:42] message
+//
+// The severity is stored in the levl field and the thread id in the tid field.
+// Glog does not log the year, which is taken to be the year told by the clock,
+// or the previous year if that would put the entry more than a day in the future.
+// The time is local unless log.LUTC is set. The flags select the fields that
+// are written, as with the standard logger, so that the file name and line number
+// require log.Lshortfile and the time requires log.Ldate and log.Lmicroseconds.
+// The prefix is ignored. It is meant for NewReader:
+//
+//	flags := log.LstdFlags | log.Lmicroseconds | log.Lshortfile | slog.Lmessage | slog.Lparsefields
+//	r := slog.NewReader(os.Stdin, "", flags, slog.GlogInput())
+func GlogInput() Option {
+	return func(l *logwriter) {
+		l.input = parseGlog
+	}
+}
+
+func parseGlog(l *logwriter, e *Entry, text string) error {
+	fields := e.Fields[:0]
+	*e = Entry{}
+
+	text = strings.TrimRightFunc(text, unicode.IsSpace)
+	e.Raw = text
+
+	// Lmmdd hh:mm:ss.uuuuuu
+	if len(text) < 22 || text[5] != ' ' || text[8] != ':' || text[11] != ':' || text[14] != '.' || text[21] != ' ' {
+		return ErrMalformed
+	}
+	level, ok := glogLevels[text[0]]
+	if !ok {
+		return ErrMalformed
+	}
+	month, ok1 := atoiFixed(text[1:3])
+	day, ok2 := atoiFixed(text[3:5])
+	hour, ok3 := atoiFixed(text[6:8])
+	min, ok4 := atoiFixed(text[9:11])
+	sec, ok5 := atoiFixed(text[12:14])
+	usec, ok6 := atoiFixed(text[15:21])
+	if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 || !ok6 {
+		return ErrMalformed
+	}
+
+	// threadid file:line]
+	rest := strings.TrimLeft(text[22:], " ")
+	i := strings.IndexByte(rest, ' ')
+	if i < 1 {
+		return ErrMalformed
+	}
+	tid, ok := atoiFixed(rest[:i])
+	if !ok {
+		return ErrMalformed
+	}
+	rest = rest[i+1:]
+	j := strings.IndexByte(rest, ']')
+	if j == -1 {
+		return ErrMalformed
+	}
+	k := strings.LastIndexByte(rest[:j], ':')
+	if k < 1 {
+		return ErrMalformed
+	}
+	line, ok := atoiFixed(rest[k+1 : j])
+	if !ok {
+		return ErrMalformed
+	}
+	e.File, e.Line = rest[:k], line
+	if l.flags&log.Lshortfile != 0 {
+		e.File = e.File[strings.LastIndexByte(e.File, '/')+1:]
+	}
+
+	now := l.now()
+	e.Time = time.Date(now.Year(), time.Month(month), day, hour, min, sec, usec*1000, now.Location())
+	if e.Time.Sub(now) > 24*time.Hour {
+		e.Time = e.Time.AddDate(-1, 0, 0)
+	}
+
+	e.Message = strings.TrimPrefix(rest[j+1:], " ")
+	fields = append(fields,
+		Field{"levl", StringValue(level.String())},
+		Field{"tid", IntValue(int64(tid))})
+	if l.flags&Lparsefields != 0 {
+		fields = appendFields(fields, e.Message)
+	}
+	e.Fields = fields
+	return nil
+}
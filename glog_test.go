@@ -0,0 +1,34 @@
+package slog
+
+import (
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGlogInput(t *testing.T) {
+	in := "I0601 12:00:00.123456   12345 server.go:42] listening port=8080\n" +
+		"E0602 01:02:03.000001 7 pkg/db/conn.go:7] \"Connection failed\" err=\"timeout\"\n" +
+		"not a glog line\n"
+
+	flags := log.LstdFlags | log.Lmicroseconds | log.LUTC | log.Lshortfile | Lmessage | Lparsefields
+	b, err := io.ReadAll(NewReader(strings.NewReader(in), "", flags, GlogInput()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	year := time.Now().UTC().Year()
+	if time.Date(year, 6, 1, 0, 0, 0, 0, time.UTC).Sub(time.Now()) > 24*time.Hour {
+		year--
+	}
+	y := strconv.Itoa(year)
+	exp := `{"time":"` + y + `-06-01T12:00:00.123456Z","fnam":"server.go","flno":42,"mesg":"listening port=8080","levl":"info","tid":12345,"port":8080}` + "\n" +
+		`{"time":"` + y + `-06-02T01:02:03.000001Z","fnam":"conn.go","flno":7,"mesg":"\"Connection failed\" err=\"timeout\"","levl":"error","tid":7,"err":"timeout"}` + "\n" +
+		`{"mesg":"not a glog line"}` + "\n"
+	if string(b) != exp {
+		t.Fatal(string(b))
+	}
+}
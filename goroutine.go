@@ -0,0 +1,43 @@
+package slog
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// recoverPanic logs a panic of the calling goroutine like LogPanic.
+func recoverPanic(l *log.Logger) {
+	if v := recover(); v != nil {
+		LogPanic(context.Background(), l, v)
+	}
+}
+
+// Go runs f in a new goroutine. A panic in f is recovered and logged at the
+// error level with the panic and stack fields, so that crashes of background
+// goroutines are never silent. The panic does not terminate the program.
+//
+//	slog.Go(l, func() { consume(queue) })
+func Go(l *log.Logger, f func()) {
+	go func() {
+		defer recoverPanic(l)
+		f()
+	}()
+}
+
+// GoWait is like Go and adds the goroutine to wg, which is done when f returns or panics.
+//
+//	var wg sync.WaitGroup
+//	for _, job := range jobs {
+//		job := job
+//		slog.GoWait(l, &wg, func() { job.Run() })
+//	}
+//	wg.Wait()
+func GoWait(l *log.Logger, wg *sync.WaitGroup, f func()) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer recoverPanic(l)
+		f()
+	}()
+}
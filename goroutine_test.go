@@ -0,0 +1,25 @@
+package slog
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestGoWait(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields)
+
+	var wg sync.WaitGroup
+	GoWait(l, &wg, func() { panic("boom") })
+	wg.Wait()
+
+	if s := b.String(); !strings.HasPrefix(s, `{"levl":"error","panic":"boom","stack":"goroutine `) {
+		t.Fatal(s)
+	}
+
+	done := make(chan struct{})
+	Go(l, func() { close(done) })
+	<-done
+}
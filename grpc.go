@@ -0,0 +1,96 @@
+package slog
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+// GRPCLogger adapts a logger to the grpclog.LoggerV2 interface of gRPC,
+// so that the internal logs of gRPC are written as structured entries.
+// The level is stored in the levl field.
+//
+//	grpclog.SetLoggerV2(slog.NewGRPCLogger(slog.Named(l, "grpc"), 0))
+type GRPCLogger struct {
+	l         *log.Logger
+	verbosity int
+}
+
+// NewGRPCLogger creates a gRPC logger that writes to l.
+// Verbosity is the level up to which V returns true, as set
+// by the GRPC_GO_LOG_VERBOSITY_LEVEL environment variable.
+func NewGRPCLogger(l *log.Logger, verbosity int) *GRPCLogger {
+	return &GRPCLogger{l, verbosity}
+}
+
+func (g *GRPCLogger) output(level Level, msg string) {
+	_ = g.l.Output(3, msg+" levl="+level.String())
+}
+
+// Info logs at the info level.
+func (g *GRPCLogger) Info(args ...interface{}) { g.output(LevelInfo, fmt.Sprint(args...)) }
+
+// Infoln logs at the info level.
+func (g *GRPCLogger) Infoln(args ...interface{}) { g.output(LevelInfo, sprintln(args)) }
+
+// Infof logs at the info level.
+func (g *GRPCLogger) Infof(format string, args ...interface{}) {
+	g.output(LevelInfo, fmt.Sprintf(format, args...))
+}
+
+// Warning logs at the warn level.
+func (g *GRPCLogger) Warning(args ...interface{}) { g.output(LevelWarn, fmt.Sprint(args...)) }
+
+// Warningln logs at the warn level.
+func (g *GRPCLogger) Warningln(args ...interface{}) { g.output(LevelWarn, sprintln(args)) }
+
+// Warningf logs at the warn level.
+func (g *GRPCLogger) Warningf(format string, args ...interface{}) {
+	g.output(LevelWarn, fmt.Sprintf(format, args...))
+}
+
+// Error logs at the error level.
+func (g *GRPCLogger) Error(args ...interface{}) { g.output(LevelError, fmt.Sprint(args...)) }
+
+// Errorln logs at the error level.
+func (g *GRPCLogger) Errorln(args ...interface{}) { g.output(LevelError, sprintln(args)) }
+
+// Errorf logs at the error level.
+func (g *GRPCLogger) Errorf(format string, args ...interface{}) {
+	g.output(LevelError, fmt.Sprintf(format, args...))
+}
+
+// Fatal logs at the fatal level and calls os.Exit(1).
+func (g *GRPCLogger) Fatal(args ...interface{}) {
+	g.output(LevelFatal, fmt.Sprint(args...))
+	os.Exit(1)
+}
+
+// Fatalln logs at the fatal level and calls os.Exit(1).
+func (g *GRPCLogger) Fatalln(args ...interface{}) {
+	g.output(LevelFatal, sprintln(args))
+	os.Exit(1)
+}
+
+// Fatalf logs at the fatal level and calls os.Exit(1).
+func (g *GRPCLogger) Fatalf(format string, args ...interface{}) {
+	g.output(LevelFatal, fmt.Sprintf(format, args...))
+	os.Exit(1)
+}
+
+// V reports whether the verbosity level l is enabled.
+func (g *GRPCLogger) V(l int) bool { return l <= g.verbosity }
+
+// sprintln formats like fmt.Sprintln without the trailing newline.
+func sprintln(args []interface{}) string {
+	s := fmt.Sprintln(args...)
+	return s[:len(s)-1]
+}
+
+func init() {
+	registerWrappers((*GRPCLogger).output,
+		(*GRPCLogger).Info, (*GRPCLogger).Infoln, (*GRPCLogger).Infof,
+		(*GRPCLogger).Warning, (*GRPCLogger).Warningln, (*GRPCLogger).Warningf,
+		(*GRPCLogger).Error, (*GRPCLogger).Errorln, (*GRPCLogger).Errorf,
+		(*GRPCLogger).Fatal, (*GRPCLogger).Fatalln, (*GRPCLogger).Fatalf)
+}
@@ -0,0 +1,43 @@
+package slog
+
+import (
+	"bytes"
+	"log"
+	"testing"
+)
+
+// loggerV2 mirrors the methods of grpclog.LoggerV2.
+type loggerV2 interface {
+	Info(args ...interface{})
+	Infoln(args ...interface{})
+	Infof(format string, args ...interface{})
+	Warning(args ...interface{})
+	Warningln(args ...interface{})
+	Warningf(format string, args ...interface{})
+	Error(args ...interface{})
+	Errorln(args ...interface{})
+	Errorf(format string, args ...interface{})
+	Fatal(args ...interface{})
+	Fatalln(args ...interface{})
+	Fatalf(format string, args ...interface{})
+	V(l int) bool
+}
+
+var _ loggerV2 = (*GRPCLogger)(nil)
+
+func TestGRPCLogger(t *testing.T) {
+	var b bytes.Buffer
+	g := NewGRPCLogger(New(&b, "", log.Lshortfile|Lfuncname|Lparsefields|Lmessage), 1)
+
+	g.Infof("dialing %s", "localhost")
+	g.Warningln("transport", "closing")
+
+	want := `{"fnam":"grpc_test.go","flno":32,"func":"slog.TestGRPCLogger","mesg":"dialing localhost levl=info","levl":"info"}` + "\n" +
+		`{"fnam":"grpc_test.go","flno":33,"func":"slog.TestGRPCLogger","mesg":"transport closing levl=warn","levl":"warn"}` + "\n"
+	if b.String() != want {
+		t.Fatal(b.String())
+	}
+	if !g.V(1) || g.V(2) {
+		t.Fatal("verbosity")
+	}
+}
@@ -0,0 +1,156 @@
+//go:build go1.21
+// +build go1.21
+
+package slog
+
+import (
+	"context"
+	"io"
+	"log"
+	stdslog "log/slog"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Handler is a log/slog handler that produces the same output as a logger created by New.
+// Attributes are stored as fields, and the keys of attributes in groups
+// are prefixed with the group names joined by dots, as in "request.method".
+// The level is stored in the levl field.
+type Handler struct {
+	lw    *logwriter
+	level stdslog.Leveler
+	group string
+	attrs []Field
+}
+
+// NewHandler creates a log/slog handler that writes to w.
+// The flags and options have the same meaning as for New, with the exception
+// that the message is never parsed for fields. The flags should include Lmessage.
+// Records below the level are discarded. The level defaults to info if nil.
+func NewHandler(w io.Writer, flags int, level stdslog.Leveler, opts ...Option) *Handler {
+	if level == nil {
+		level = stdslog.LevelInfo
+	}
+	lw := newLogwriter(w, "", flags, opts)
+	lw.setColor(w)
+	return &Handler{lw: lw, level: level}
+}
+
+// Enabled implements log/slog.Handler.
+func (h *Handler) Enabled(_ context.Context, level stdslog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// levelName maps the levels of log/slog to the levels of slog,
+// so that debug, info, warn and error keep their names.
+func levelName(level stdslog.Level) string {
+	if s, ok := DefaultLevels.Name(Level(level/4+2) * 10); ok && level%4 == 0 {
+		return s
+	}
+	return strings.ToLower(level.String())
+}
+
+// Handle implements log/slog.Handler.
+func (h *Handler) Handle(_ context.Context, r stdslog.Record) error {
+	lw := h.lw
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	e := &lw.entry
+	*e = Entry{Fields: e.Fields[:0], Message: r.Message}
+
+	if lw.flags&(log.Ldate|log.Ltime|log.Lmicroseconds|Ltimestamp) != 0 && !r.Time.IsZero() {
+		if e.Time = r.Time; lw.clock != nil {
+			e.Time = lw.now()
+		} else if lw.flags&log.LUTC != 0 {
+			e.Time = e.Time.UTC()
+		}
+	}
+
+	if lw.flags&(log.Llongfile|log.Lshortfile|Lfuncname) != 0 && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		if lw.flags&(log.Llongfile|log.Lshortfile) != 0 {
+			e.File, e.Line = frame.File, frame.Line
+			if lw.flags&log.Lshortfile != 0 {
+				e.File = e.File[strings.LastIndexByte(e.File, '/')+1:]
+			}
+		}
+		if lw.flags&Lfuncname != 0 {
+			e.Func = trimFuncPath(frame.Function)
+		}
+	}
+
+	e.Fields = append(e.Fields, Field{"levl", StringValue(levelName(r.Level))})
+	e.Fields = append(e.Fields, h.attrs...)
+	r.Attrs(func(a stdslog.Attr) bool {
+		e.Fields = appendAttr(e.Fields, h.group, a)
+		return true
+	})
+	e.Fields = append(e.Fields, lw.static...)
+
+	return lw.emit(e, "")
+}
+
+// WithAttrs implements log/slog.Handler.
+func (h *Handler) WithAttrs(attrs []stdslog.Attr) stdslog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.lw = h.lw.with()
+	h2.attrs = h.attrs[:len(h.attrs):len(h.attrs)]
+	for _, a := range attrs {
+		h2.attrs = appendAttr(h2.attrs, h.group, a)
+	}
+	return &h2
+}
+
+// WithGroup implements log/slog.Handler.
+func (h *Handler) WithGroup(name string) stdslog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.lw = h.lw.with()
+	h2.group = h.group + name + "."
+	return &h2
+}
+
+// appendAttr appends an attribute as fields, flattening groups.
+func appendAttr(fields []Field, group string, a stdslog.Attr) []Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(stdslog.Attr{}) {
+		return fields
+	}
+
+	if a.Value.Kind() == stdslog.KindGroup {
+		if a.Key != "" {
+			group += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendAttr(fields, group, ga)
+		}
+		return fields
+	}
+
+	return append(fields, Field{group + a.Key, attrValue(a.Value)})
+}
+
+func attrValue(v stdslog.Value) Value {
+	switch v.Kind() {
+	case stdslog.KindString:
+		return StringValue(v.String())
+	case stdslog.KindInt64:
+		return IntValue(v.Int64())
+	case stdslog.KindUint64:
+		return uintValue(v.Uint64())
+	case stdslog.KindFloat64:
+		return FloatValue(v.Float64())
+	case stdslog.KindBool:
+		return BoolValue(v.Bool())
+	case stdslog.KindTime:
+		return StringValue(v.Time().Format(time.RFC3339Nano))
+	}
+	return AnyValue(v.Any())
+}
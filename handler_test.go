@@ -0,0 +1,25 @@
+//go:build go1.21
+// +build go1.21
+
+package slog
+
+import (
+	"bytes"
+	stdslog "log/slog"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	var b bytes.Buffer
+	l := stdslog.New(NewHandler(&b, Lmessage, nil))
+	l = l.With("app", "api").WithGroup("req")
+	l.Info("hello", "method", "GET", stdslog.Group("user", "id", 7))
+	l.Debug("dropped")
+	l.Warn("careful")
+
+	want := `{"mesg":"hello","levl":"info","app":"api","req.method":"GET","req.user.id":7}` + "\n" +
+		`{"mesg":"careful","levl":"warn","app":"api"}` + "\n"
+	if b.String() != want {
+		t.Fatal(b.String())
+	}
+}
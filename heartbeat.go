@@ -0,0 +1,62 @@
+package slog
+
+import (
+	"log"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// HeartbeatOptions configures Heartbeat.
+type HeartbeatOptions struct {
+	// Every is the interval between heartbeats. Defaults to one minute.
+	Every time.Duration
+
+	// Clock tells the time used to compute the uptime. Defaults to SystemClock.
+	Clock Clock
+}
+
+type heartbeat struct {
+	l     *log.Logger
+	clock Clock
+	start time.Time
+	seq   uint64
+}
+
+func (h *heartbeat) beat() {
+	h.seq++
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	h.l.Printf("heartbeat seq=%d uptime=%.3f goroutines=%d heapmb=%.1f gcs=%d",
+		h.seq, h.clock.Now().Sub(h.start).Seconds(), runtime.NumGoroutine(),
+		float64(ms.HeapAlloc)/(1<<20), ms.NumGC)
+}
+
+// Heartbeat logs a heartbeat entry to l at every interval, so that missing heartbeats
+// in the aggregator indicate a wedged or dead process. The entries have the fields
+// seq, the sequence number starting at 1, uptime, the seconds since Heartbeat
+// was called, goroutines, heapmb, the allocated heap in megabytes, and gcs,
+// the number of garbage collections. The returned function stops the heartbeat.
+func Heartbeat(l *log.Logger, opts HeartbeatOptions) (stop func()) {
+	if opts.Every <= 0 {
+		opts.Every = time.Minute
+	}
+	clock := clockOrSystem(opts.Clock)
+	h := &heartbeat{l: l, clock: clock, start: clock.Now()}
+
+	done := make(chan struct{})
+	var once sync.Once
+	go func() {
+		t := time.NewTicker(opts.Every)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				h.beat()
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() { once.Do(func() { close(done) }) }
+}
@@ -0,0 +1,25 @@
+package slog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeartbeat(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields)
+	h := &heartbeat{l: l, clock: fixedClock(time.Unix(90, 0)), start: time.Unix(0, 0)}
+	h.beat()
+	h.beat()
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[1], `{"seq":2,"uptime":90,"goroutines":`) {
+		t.Fatal(b.String())
+	}
+
+	stop := Heartbeat(New(&bytes.Buffer{}, "", 0), HeartbeatOptions{Every: time.Millisecond})
+	stop()
+	stop()
+}
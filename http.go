@@ -0,0 +1,254 @@
+package slog
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"time"
+)
+
+// DefaultRequestIDHeader is the default header that carries the request id.
+const DefaultRequestIDHeader = "X-Request-Id"
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx that carries the request id.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request id carried by ctx, if any.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// FromContext returns a logger derived from l that adds the request id carried by ctx
+// to every entry in the reqid field. It returns l if ctx carries no request id
+// or if l does not write to a structured writer.
+func FromContext(ctx context.Context, l *log.Logger) *log.Logger {
+	if id := RequestID(ctx); id != "" {
+		if lw, ok := l.Writer().(*logwriter); ok {
+			return log.New(lw.with(Field{"reqid", StringValue(id)}), l.Prefix(), l.Flags())
+		}
+	}
+	return l
+}
+
+// MiddlewareOptions configures the HTTP middleware.
+type MiddlewareOptions struct {
+	// RequestIDHeader is the header that carries the request id.
+	// A new id is generated if the request does not have one.
+	// Defaults to DefaultRequestIDHeader.
+	RequestIDHeader string
+
+	// Clock tells the time used to measure the duration of requests.
+	// Defaults to SystemClock.
+	Clock Clock
+
+	// BodyLimit is the number of bytes of the request and response bodies
+	// that are logged in the reqbody and resbody fields.
+	// Truncated bodies end with an ellipsis. Bodies are not logged if zero.
+	BodyLimit int
+
+	// BodyTypes lists the content types of the bodies that are logged.
+	// A type ending in a slash matches all subtypes. Defaults to DefaultBodyTypes.
+	BodyTypes []string
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	status  int
+	size    int64
+	body    *bodyCapture
+	types   []string
+	checked bool
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(p)
+	r.size += int64(n)
+	if r.body != nil && !r.checked {
+		r.checked = true
+		contentType := r.Header().Get("Content-Type")
+		if contentType == "" {
+			contentType = http.DetectContentType(p)
+		}
+		if !bodyTypeAllowed(contentType, r.types) {
+			r.body = nil
+		}
+	}
+	if r.body != nil {
+		r.body.capture(p[:n])
+	}
+	return n, err
+}
+
+func (r *responseRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack lets handlers behind Middleware take over the connection,
+// for example to serve WebSockets. The request is logged with status 101.
+func (r *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	conn, rw, err := hj.Hijack()
+	if err == nil && r.status == 0 {
+		r.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController.
+func (r *responseRecorder) Unwrap() http.ResponseWriter { return r.ResponseWriter }
+
+// Middleware returns HTTP middleware that assigns a request id to every request
+// and logs one entry per request with the fields method, path, status, size and durms,
+// the duration in milliseconds. The request id is taken from the request header
+// or generated, echoed in the response header and stored in the request context,
+// so that handlers can use FromContext to log entries with the same reqid field.
+// The bodies are logged in the reqbody and resbody fields if opts.BodyLimit is set.
+func Middleware(l *log.Logger, opts MiddlewareOptions) func(http.Handler) http.Handler {
+	if opts.RequestIDHeader == "" {
+		opts.RequestIDHeader = DefaultRequestIDHeader
+	}
+	clock := clockOrSystem(opts.Clock)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := clock.Now()
+
+			id := r.Header.Get(opts.RequestIDHeader)
+			if id == "" {
+				id = newUUIDv7(start)
+			}
+			w.Header().Set(opts.RequestIDHeader, id)
+
+			ctx := ContextWithRequestID(r.Context(), id)
+			r = r.WithContext(ctx)
+			rec := responseRecorder{ResponseWriter: w}
+
+			var reqBody *bodyCapture
+			if opts.BodyLimit > 0 {
+				if r.Body != nil && bodyTypeAllowed(r.Header.Get("Content-Type"), opts.BodyTypes) {
+					reqBody = &bodyCapture{limit: opts.BodyLimit}
+					r.Body = &captureReader{r.Body, reqBody}
+				}
+				rec.body = &bodyCapture{limit: opts.BodyLimit}
+				rec.types = opts.BodyTypes
+			}
+
+			next.ServeHTTP(&rec, r)
+
+			if rec.status == 0 {
+				rec.status = http.StatusOK
+			}
+
+			FromContext(ctx, l).Print(requestFields(r.Method, r.URL.Path, rec.status, rec.size, msSince(clock, start)) +
+				reqBody.field("reqbody") + rec.body.field("resbody"))
+		})
+	}
+}
+
+func requestFields(method, path string, status int, size int64, durms float64) string {
+	return fmt.Sprintf("method=%s path=%s status=%d size=%d durms=%.3f",
+		method, quoteValue(path), status, size, durms)
+}
+
+// RequestInfo describes a request served by an HTTP handler.
+type RequestInfo struct {
+	Method   string
+	Path     string
+	Status   int
+	Size     int64
+	Duration time.Duration
+}
+
+// LogRequest logs a served request with the same fields as Middleware and the
+// request id carried by ctx. It is meant for web frameworks that do not use
+// net/http middleware, such as Gin:
+//
+//	r.Use(func(c *gin.Context) {
+//		start := time.Now()
+//		c.Next()
+//		slog.LogRequest(c.Request.Context(), l, slog.RequestInfo{
+//			Method:   c.Request.Method,
+//			Path:     c.Request.URL.Path,
+//			Status:   c.Writer.Status(),
+//			Size:     int64(c.Writer.Size()),
+//			Duration: time.Since(start),
+//		})
+//	})
+//
+// Frameworks that adapt net/http middleware, such as Echo, can use Middleware directly:
+//
+//	e.Use(echo.WrapMiddleware(slog.Middleware(l, slog.MiddlewareOptions{})))
+//
+// The sloggin and slogecho modules implement ready-made middleware for Gin and Echo.
+func LogRequest(ctx context.Context, l *log.Logger, info RequestInfo) {
+	durms := float64(info.Duration) / float64(time.Millisecond)
+	FromContext(ctx, l).Print(requestFields(info.Method, info.Path, info.Status, info.Size, durms))
+}
+
+// Recover returns HTTP middleware that recovers from panics in the handler,
+// logs them at the error level with the panic and stack fields and responds
+// with status 500, unless the handler already wrote the response header.
+// The http.ErrAbortHandler panic is not recovered.
+// Add it after Middleware so that the entry carries the request id.
+// Echo applications can use it through the adapter of the framework:
+//
+//	e.Use(echo.WrapMiddleware(slog.Recover(l)))
+func Recover(l *log.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := responseRecorder{ResponseWriter: w}
+			defer func() {
+				if v := recover(); v != nil {
+					if v == http.ErrAbortHandler {
+						panic(v)
+					}
+					LogPanic(r.Context(), l, v)
+					if rec.status == 0 {
+						w.WriteHeader(http.StatusInternalServerError)
+					}
+				}
+			}()
+			next.ServeHTTP(&rec, r)
+		})
+	}
+}
+
+// LogPanic logs a recovered panic value at the error level with the panic and
+// stack fields and the request id carried by ctx. It is meant for the recovery
+// handlers of web frameworks, such as Gin:
+//
+//	r.Use(gin.CustomRecovery(func(c *gin.Context, v interface{}) {
+//		slog.LogPanic(c.Request.Context(), l, v)
+//		c.AbortWithStatus(http.StatusInternalServerError)
+//	}))
+func LogPanic(ctx context.Context, l *log.Logger, v interface{}) {
+	FromContext(ctx, l).Print("levl=error " + KV("panic", fmt.Sprint(v)) + " " + KV("stack", string(debug.Stack())))
+}
+
+func msSince(clock Clock, t time.Time) float64 {
+	return float64(clock.Now().Sub(t)) / float64(time.Millisecond)
+}
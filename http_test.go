@@ -0,0 +1,188 @@
+package slog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMiddleware(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields)
+
+	h := Middleware(l, MiddlewareOptions{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context(), l).Println("handling user=bob")
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = io.WriteString(w, "hello")
+	}))
+
+	req := httptest.NewRequest("GET", "/a%20b", nil)
+	req.Header.Set("X-Request-Id", "abc")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Header().Get("X-Request-Id") != "abc" {
+		t.Fatal(rec.Header())
+	}
+
+	var entries []map[string]interface{}
+	d := json.NewDecoder(&b)
+	for d.More() {
+		var m map[string]interface{}
+		if err := d.Decode(&m); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, m)
+	}
+
+	if len(entries) != 2 {
+		t.Fatal(entries)
+	} else if entries[0]["user"] != "bob" || entries[0]["reqid"] != "abc" {
+		t.Fatal(entries[0])
+	} else if e := entries[1]; e["method"] != "GET" || e["path"] != "/a b" || e["status"] != 418.0 || e["size"] != 5.0 || e["reqid"] != "abc" {
+		t.Fatal(e)
+	} else if _, ok := e["durms"].(float64); !ok {
+		t.Fatal(e)
+	}
+}
+
+func TestMiddlewareGeneratesRequestID(t *testing.T) {
+	l := log.New(io.Discard, "", 0)
+	h := Middleware(l, MiddlewareOptions{RequestIDHeader: "X-Trace"})(http.NotFoundHandler())
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if len(rec.Header().Get("X-Trace")) != 36 {
+		t.Fatal(rec.Header())
+	}
+}
+
+func TestMiddlewareWebSocket(t *testing.T) {
+	pr, pw := io.Pipe()
+	l := New(pw, "", Lparsefields)
+	tail := NewTail(TailOptions{})
+	srv := httptest.NewServer(Middleware(l, MiddlewareOptions{})(tail.WebSocket()))
+	defer srv.Close()
+
+	conn, r := dialTail(t, srv.Listener.Addr().String(), "")
+	defer conn.Close()
+	_, _ = conn.Write([]byte{0x88, 0x80, 0, 0, 0, 0})
+	if op, _ := readFrame(t, r); op != wsClose {
+		t.Fatal(op)
+	}
+
+	line, err := bufio.NewReader(pr).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	} else if !strings.Contains(line, `"status":101`) {
+		t.Fatal(line)
+	}
+
+	w := httptest.NewRecorder()
+	if rec := (&responseRecorder{ResponseWriter: w}); rec.Unwrap() != w {
+		t.Fatal("unwrap")
+	}
+}
+
+func TestMiddlewareBody(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields)
+	h := Middleware(l, MiddlewareOptions{BodyLimit: 8})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"alice"}`))
+	}))
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader("a=1&b=2"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !strings.Contains(b.String(), `"reqbody":"a=1&b=2"`) || !strings.Contains(b.String(), `"resbody":"{'name':..."`) {
+		t.Fatal(b.String())
+	}
+
+	b.Reset()
+	req = httptest.NewRequest("POST", "/", strings.NewReader("binary"))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if strings.Contains(b.String(), "reqbody") {
+		t.Fatal(b.String())
+	}
+}
+
+func TestLogRequest(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields)
+	ctx := ContextWithRequestID(context.Background(), "abc")
+	LogRequest(ctx, l, RequestInfo{"GET", "/a b", 201, 5, 1500 * time.Microsecond})
+
+	if want := `{"method":"GET","path":"/a b","status":201,"size":5,"durms":1.5,"reqid":"abc"}` + "\n"; b.String() != want {
+		t.Fatal(b.String())
+	}
+}
+
+func TestRecover(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields)
+	h := Middleware(l, MiddlewareOptions{})(Recover(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatal(rec.Code)
+	}
+
+	d := NewDecoder(&b)
+	var e Entry
+	if err := d.Decode(&e); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := e.Get("panic"); v.String() != "boom" {
+		t.Fatal(e)
+	}
+	if v, _ := e.Get("stack"); !strings.Contains(v.String(), "goroutine") {
+		t.Fatal(e)
+	}
+	if _, ok := e.Get("reqid"); !ok {
+		t.Fatal(e)
+	}
+	if err := d.Decode(&e); err != nil {
+		t.Fatal(err)
+	} else if v, _ := e.Get("status"); v.Int() != 500 {
+		t.Fatal(e)
+	}
+}
+
+type headerCounter struct {
+	http.ResponseWriter
+	headers int
+}
+
+func (w *headerCounter) WriteHeader(status int) {
+	w.headers++
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func TestRecoverAfterWrite(t *testing.T) {
+	l := log.New(io.Discard, "", 0)
+	h := Recover(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	w := headerCounter{ResponseWriter: rec}
+	h.ServeHTTP(&w, httptest.NewRequest("GET", "/", nil))
+	if rec.Code != http.StatusAccepted || w.headers != 1 {
+		t.Fatal(rec.Code, w.headers)
+	}
+}
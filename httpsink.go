@@ -0,0 +1,204 @@
+package slog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Body formats of an HTTP writer.
+const (
+	// HTTPNDJSON sends the entries separated by newlines.
+	HTTPNDJSON = "ndjson"
+
+	// HTTPArray sends the entries as a JSON array.
+	HTTPArray = "array"
+)
+
+// HTTPOptions configures an HTTP writer.
+type HTTPOptions struct {
+	// Header is added to every request, such as an Authorization
+	// header or the API key of the endpoint.
+	Header http.Header
+
+	// Format is the format of the body, HTTPNDJSON or HTTPArray.
+	// Defaults to HTTPNDJSON.
+	Format string
+
+	// Gzip compresses the body and sets the Content-Encoding header.
+	Gzip bool
+
+	// MaxEntries and MaxBytes limit the size of a batch.
+	// They default to 1000 entries and 1 MiB. Entries larger than MaxBytes
+	// are rejected with ErrRecordTooLarge.
+	MaxEntries int
+	MaxBytes   int
+
+	// Interval is the maximum time that entries are buffered.
+	// Defaults to five seconds.
+	Interval time.Duration
+
+	// Retries is the maximum number of times that a batch is retried after
+	// a network error, a 429 response or a 5xx response. Defaults to five.
+	// Batches are not retried if negative.
+	Retries int
+
+	// Backoff is the delay before the first retry, which doubles with every retry
+	// up to MaxBackoff. Delays are randomized by up to half to spread the retries
+	// of many processes. The Retry-After header of a 429 or 503 response takes
+	// precedence. Backoff defaults to half a second and MaxBackoff to thirty seconds.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// Client is the HTTP client used to send the batches.
+	// Defaults to a client with a thirty second timeout.
+	Client *http.Client
+}
+
+type httpwriter struct {
+	url   string
+	opts  HTTPOptions
+	sleep func(time.Duration)
+}
+
+func (w *httpwriter) body(recs [][]byte) ([]byte, error) {
+	var b bytes.Buffer
+	var dst io.Writer = &b
+	var zw *gzip.Writer
+	if w.opts.Gzip {
+		zw = gzip.NewWriter(&b)
+		dst = zw
+	}
+
+	if w.opts.Format == HTTPArray {
+		_, _ = io.WriteString(dst, "[")
+		for i, rec := range recs {
+			if i > 0 {
+				_, _ = io.WriteString(dst, ",")
+			}
+			_, _ = dst.Write(bytes.TrimRight(rec, "\n"))
+		}
+		_, _ = io.WriteString(dst, "]")
+	} else {
+		for _, rec := range recs {
+			_, _ = dst.Write(rec)
+			if len(rec) == 0 || rec[len(rec)-1] != '\n' {
+				_, _ = io.WriteString(dst, "\n")
+			}
+		}
+	}
+
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// send posts the body once and returns the delay requested by the server, if any.
+func (w *httpwriter) send(body []byte) (time.Duration, bool, error) {
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, err
+	}
+	for key, vals := range w.opts.Header {
+		req.Header[key] = vals
+	}
+	if w.opts.Format == HTTPArray {
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req.Header.Set("Content-Type", "application/x-ndjson")
+	}
+	if w.opts.Gzip {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	res, err := w.opts.Client.Do(req)
+	if err != nil {
+		return 0, true, err
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return 0, false, nil
+	}
+	err = fmt.Errorf("slog: http: %s", res.Status)
+	retry := res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+	var after time.Duration
+	if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable {
+		if secs, perr := strconv.Atoi(res.Header.Get("Retry-After")); perr == nil && secs > 0 {
+			after = time.Duration(secs) * time.Second
+		}
+	}
+	return after, retry, err
+}
+
+func (w *httpwriter) post(recs [][]byte) error {
+	body, err := w.body(recs)
+	if err != nil {
+		return err
+	}
+
+	backoff := w.opts.Backoff
+	for retries := 0; ; retries++ {
+		after, retry, err := w.send(body)
+		if !retry || retries >= w.opts.Retries {
+			return err
+		}
+		if after == 0 {
+			after = backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+		}
+		w.sleep(after)
+		if backoff *= 2; backoff > w.opts.MaxBackoff {
+			backoff = w.opts.MaxBackoff
+		}
+	}
+}
+
+// NewHTTPWriter creates a writer that posts batches of entries to the URL,
+// covering the many log endpoints that accept NDJSON or JSON arrays over HTTP.
+// A batch is sent when it reaches the size limits or when the interval elapses,
+// and retried with exponential backoff. A batch that still fails is sent again
+// at the next flush. Errors of asynchronous sends are returned by the next call
+// to Write or Close. Close must be called to send the last batch.
+//
+//	w := slog.NewHTTPWriter("https://logs.example.com/v1/ingest", slog.HTTPOptions{
+//		Header: http.Header{"Authorization": {"Bearer " + token}},
+//		Gzip:   true,
+//	})
+func NewHTTPWriter(url string, opts HTTPOptions) io.WriteCloser {
+	if opts.Format == "" {
+		opts.Format = HTTPNDJSON
+	}
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = 1000
+	}
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = 1 << 20
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 5 * time.Second
+	}
+	if opts.Retries == 0 {
+		opts.Retries = 5
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = 500 * time.Millisecond
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = 30 * time.Second
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	w := &httpwriter{url: url, opts: opts, sleep: time.Sleep}
+	return newBatcher(opts.MaxEntries, opts.MaxBytes, opts.MaxBytes, opts.Interval, w.post)
+}
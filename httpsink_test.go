@@ -0,0 +1,78 @@
+package slog
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPWriter(t *testing.T) {
+	var body, auth, ctype string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, ctype = r.Header.Get("Authorization"), r.Header.Get("Content-Type")
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		data, _ := io.ReadAll(zr)
+		body = string(data)
+	}))
+	defer srv.Close()
+
+	w := NewHTTPWriter(srv.URL, HTTPOptions{
+		Header: http.Header{"Authorization": {"Bearer x"}},
+		Format: HTTPArray,
+		Gzip:   true,
+	})
+	l := New(w, "", Lparsefields)
+	l.Print("a=1")
+	l.Print("a=2")
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if body != `[{"a":1},{"a":2}]` || auth != "Bearer x" || ctype != "application/json" {
+		t.Fatal(body, auth, ctype)
+	}
+}
+
+func TestHTTPWriterRetry(t *testing.T) {
+	statuses := []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusOK}
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		status := statuses[0]
+		statuses = statuses[1:]
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "7")
+		}
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	var sleeps []time.Duration
+	w := &httpwriter{
+		url:   srv.URL,
+		opts:  HTTPOptions{Retries: 5, Backoff: time.Second, MaxBackoff: time.Minute, Client: srv.Client()},
+		sleep: func(d time.Duration) { sleeps = append(sleeps, d) },
+	}
+	if err := w.post([][]byte{[]byte("{\"a\":1}\n"), []byte(`{"a":2}`)}); err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies) != 3 || bodies[2] != "{\"a\":1}\n{\"a\":2}\n" {
+		t.Fatal(bodies)
+	}
+	// the first retry waits for Retry-After and the second for the jittered backoff of 2s
+	if len(sleeps) != 2 || sleeps[0] != 7*time.Second || sleeps[1] < time.Second || sleeps[1] > 2*time.Second {
+		t.Fatal(sleeps)
+	}
+
+	statuses = []int{http.StatusBadRequest}
+	if err := w.post([][]byte{[]byte("{}\n")}); err == nil || err.Error() != "slog: http: 400 Bad Request" {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,48 @@
+package slog
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// newUUIDv7 generates a time-ordered UUID version 7 as specified by RFC 9562.
+func newUUIDv7(now time.Time) string {
+	var u [16]byte
+	_, _ = rand.Read(u[6:])
+
+	ms := uint64(now.UnixNano() / int64(time.Millisecond))
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+	u[6] = u[6]&0x0f | 0x70
+	u[8] = u[8]&0x3f | 0x80
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// EntryID stamps every entry with a unique, time-ordered UUIDv7 in the id field,
+// so that entries can be deduplicated and referenced by downstream systems.
+// The time is told by the clock set by WithClock, if any.
+func EntryID() Option {
+	return func(l *logwriter) {
+		l.before = append(l.before, func(e *Entry) bool {
+			now := clockOrSystem(l.clock).Now()
+			e.Fields = append(e.Fields, Field{"id", StringValue(newUUIDv7(now))})
+			return true
+		})
+	}
+}
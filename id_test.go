@@ -0,0 +1,42 @@
+package slog
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestEntryID(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lmessage, EntryID())
+	l.Println("one")
+	l.Println("two")
+
+	re := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	d := json.NewDecoder(&b)
+	var ids []string
+	for d.More() {
+		var entry struct{ ID string }
+		if err := d.Decode(&entry); err != nil {
+			t.Fatal(err)
+		} else if !re.MatchString(entry.ID) {
+			t.Fatal(entry.ID)
+		}
+		ids = append(ids, entry.ID)
+	}
+
+	if len(ids) != 2 || ids[0] == ids[1] {
+		t.Fatal(ids)
+	}
+}
+
+func TestUUIDv7Ordering(t *testing.T) {
+	a := newUUIDv7(time.Unix(1, 0))
+	b := newUUIDv7(time.Unix(2, 0))
+	if a[:13] >= b[:13] {
+		t.Fatal(a, b)
+	}
+}
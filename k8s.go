@@ -0,0 +1,102 @@
+package slog
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// KubernetesOptions configures the Kubernetes option.
+// The values are exposed to the container by the Downward API,
+// either as environment variables or as files in a volume.
+type KubernetesOptions struct {
+	// PodNameEnv is the environment variable holding the pod name.
+	// Defaults to POD_NAME.
+	PodNameEnv string
+
+	// NamespaceEnv is the environment variable holding the namespace.
+	// Defaults to POD_NAMESPACE. The namespace of the service account
+	// is used if the variable is not set.
+	NamespaceEnv string
+
+	// NodeNameEnv is the environment variable holding the node name.
+	// Defaults to NODE_NAME.
+	NodeNameEnv string
+
+	// LabelsFile is the Downward API file holding the pod labels.
+	// Defaults to /etc/podinfo/labels.
+	LabelsFile string
+}
+
+const serviceAccountNamespace = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// parseLabels parses a Downward API labels file, which holds one key="value" pair per line.
+func parseLabels(name string) ([]Field, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var fields []Field
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		i := strings.IndexByte(line, '=')
+		if i == -1 {
+			continue
+		}
+		key, val := line[:i], line[i+1:]
+		if v, err := strconv.Unquote(val); err == nil {
+			val = v
+		}
+		fields = append(fields, Field{"k8s.pod.label." + key, StringValue(val)})
+	}
+	return fields, s.Err()
+}
+
+// kubernetesFields reads the pod metadata. Missing values are skipped.
+func kubernetesFields(opts KubernetesOptions) []Field {
+	var fields []Field
+	add := func(key, val string) {
+		if val = strings.TrimSpace(val); val != "" {
+			fields = append(fields, Field{key, StringValue(val)})
+		}
+	}
+
+	add("k8s.pod.name", os.Getenv(opts.PodNameEnv))
+	if ns, ok := os.LookupEnv(opts.NamespaceEnv); ok {
+		add("k8s.namespace.name", ns)
+	} else if ns, err := os.ReadFile(serviceAccountNamespace); err == nil {
+		add("k8s.namespace.name", string(ns))
+	}
+	add("k8s.node.name", os.Getenv(opts.NodeNameEnv))
+
+	labels, _ := parseLabels(opts.LabelsFile)
+	return append(fields, labels...)
+}
+
+// Kubernetes adds the pod name, namespace, node name and labels of the pod
+// to every entry in the k8s.pod.name, k8s.namespace.name, k8s.node.name
+// and k8s.pod.label.* fields, following the OpenTelemetry conventions.
+// The metadata is read once when the option is applied. Values that are
+// not exposed to the container are omitted, so that the option does nothing
+// outside of a cluster.
+func Kubernetes(opts KubernetesOptions) Option {
+	if opts.PodNameEnv == "" {
+		opts.PodNameEnv = "POD_NAME"
+	}
+	if opts.NamespaceEnv == "" {
+		opts.NamespaceEnv = "POD_NAMESPACE"
+	}
+	if opts.NodeNameEnv == "" {
+		opts.NodeNameEnv = "NODE_NAME"
+	}
+	if opts.LabelsFile == "" {
+		opts.LabelsFile = "/etc/podinfo/labels"
+	}
+	return func(l *logwriter) {
+		l.static = append(l.static, kubernetesFields(opts)...)
+	}
+}
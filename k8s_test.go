@@ -0,0 +1,31 @@
+package slog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKubernetes(t *testing.T) {
+	labels := filepath.Join(t.TempDir(), "labels")
+	if err := os.WriteFile(labels, []byte("app=\"api\"\ntier=\"back \\\"end\\\"\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer setenv("TEST_POD", "api-123")()
+	defer setenv("TEST_NS", "prod")()
+
+	var b bytes.Buffer
+	l := New(&b, "", Lmessage, Kubernetes(KubernetesOptions{
+		PodNameEnv:   "TEST_POD",
+		NamespaceEnv: "TEST_NS",
+		NodeNameEnv:  "TEST_NODE_UNSET",
+		LabelsFile:   labels,
+	}))
+	l.Print("hello")
+
+	want := `{"mesg":"hello","k8s.pod.name":"api-123","k8s.namespace.name":"prod","k8s.pod.label.app":"api","k8s.pod.label.tier":"back \"end\""}` + "\n"
+	if b.String() != want {
+		t.Fatal(b.String())
+	}
+}
@@ -0,0 +1,42 @@
+package slog
+
+import (
+	"log"
+	"os"
+	"sync/atomic"
+)
+
+// Lambda adds the name and version of the AWS Lambda function to every entry
+// in the faas.name and faas.version fields, following the OpenTelemetry conventions.
+// They are read from the environment of the Lambda runtime when the option is applied.
+// The option does nothing outside of Lambda.
+func Lambda() Option {
+	var fields []Field
+	if name := os.Getenv("AWS_LAMBDA_FUNCTION_NAME"); name != "" {
+		fields = append(fields, Field{"faas.name", StringValue(name)})
+	}
+	if version := os.Getenv("AWS_LAMBDA_FUNCTION_VERSION"); version != "" {
+		fields = append(fields, Field{"faas.version", StringValue(version)})
+	}
+	return func(l *logwriter) {
+		l.static = append(l.static, fields...)
+	}
+}
+
+var lambdaInvoked uint32
+
+// LambdaInvocation returns a logger derived from l for a single invocation
+// of a Lambda function. It adds the request id of the invocation in the
+// faas.invocation_id field and whether it is the first invocation of the
+// execution environment in the faas.coldstart field.
+// The request id is the AwsRequestID of the Lambda context:
+//
+//	func handler(ctx context.Context, event Event) error {
+//		lc, _ := lambdacontext.FromContext(ctx)
+//		l := slog.LambdaInvocation(logger, lc.AwsRequestID)
+//		...
+//	}
+func LambdaInvocation(l *log.Logger, requestID string) *log.Logger {
+	cold := atomic.CompareAndSwapUint32(&lambdaInvoked, 0, 1)
+	return With(l, "faas.invocation_id", requestID, "faas.coldstart", cold)
+}
@@ -0,0 +1,23 @@
+package slog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLambda(t *testing.T) {
+	defer setenv("AWS_LAMBDA_FUNCTION_NAME", "resize")()
+	defer setenv("AWS_LAMBDA_FUNCTION_VERSION", "$LATEST")()
+	lambdaInvoked = 0
+
+	var b bytes.Buffer
+	l := New(&b, "", Lmessage, Lambda())
+	LambdaInvocation(l, "req-1").Print("a")
+	LambdaInvocation(l, "req-2").Print("b")
+
+	want := `{"mesg":"a","faas.name":"resize","faas.version":"$LATEST","faas.invocation_id":"req-1","faas.coldstart":true}` + "\n" +
+		`{"mesg":"b","faas.name":"resize","faas.version":"$LATEST","faas.invocation_id":"req-2","faas.coldstart":false}` + "\n"
+	if b.String() != want {
+		t.Fatal(b.String())
+	}
+}
@@ -0,0 +1,261 @@
+package slog
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+)
+
+// Level is the severity of a log entry.
+// Slog detects the level of an entry from the level or levl field.
+type Level int
+
+// Levels recognized by slog.
+const (
+	LevelDebug Level = 10 * (iota + 1)
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelFatal
+
+	// LevelTrace is below LevelDebug for the most verbose diagnostics.
+	LevelTrace Level = 5
+)
+
+// levelMaps is an immutable snapshot of a LevelTable.
+type levelMaps struct {
+	levels map[string]Level
+	names  map[Level]string
+}
+
+// LevelTable maps level names to canonical names and levels.
+// It is safe for concurrent use. Lookups read an immutable snapshot
+// without locking, because they happen for every entry and definitions
+// are rare.
+type LevelTable struct {
+	mu   sync.Mutex
+	maps atomic.Value
+}
+
+// NewLevelTable creates an empty level table.
+func NewLevelTable() *LevelTable {
+	var t LevelTable
+	t.maps.Store(&levelMaps{
+		levels: map[string]Level{},
+		names:  map[Level]string{},
+	})
+	return &t
+}
+
+func (t *LevelTable) load() *levelMaps {
+	return t.maps.Load().(*levelMaps)
+}
+
+// Define defines the canonical name of a level and the aliases that map to it.
+// Names and aliases are matched case-insensitively.
+func (t *LevelTable) Define(name string, level Level, aliases ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	old := t.load()
+	m := &levelMaps{
+		levels: make(map[string]Level, len(old.levels)+len(aliases)+1),
+		names:  make(map[Level]string, len(old.names)+1),
+	}
+	for k, v := range old.levels {
+		m.levels[k] = v
+	}
+	for k, v := range old.names {
+		m.names[k] = v
+	}
+	m.names[level] = name
+	m.levels[strings.ToLower(name)] = level
+	for _, alias := range aliases {
+		m.levels[strings.ToLower(alias)] = level
+	}
+	t.maps.Store(m)
+}
+
+// Parse returns the level of a name or alias.
+func (t *LevelTable) Parse(s string) (Level, bool) {
+	m := t.load()
+	if level, ok := m.levels[s]; ok {
+		return level, true
+	}
+	level, ok := m.levels[strings.ToLower(s)]
+	return level, ok
+}
+
+// Name returns the canonical name of a level.
+func (t *LevelTable) Name(l Level) (string, bool) {
+	name, ok := t.load().names[l]
+	return name, ok
+}
+
+// DefaultLevels is the level table used by slog to detect, filter and name levels.
+// Applications may define additional levels and aliases during initialization.
+// Levels are ordered by their numeric value, so that a level defined between
+// LevelWarn and LevelError is treated as a warning by facilities such as Lpriority.
+var DefaultLevels = NewLevelTable()
+
+func init() {
+	DefaultLevels.Define("trace", LevelTrace, "trce", "trc")
+	DefaultLevels.Define("debug", LevelDebug, "dbug", "dbg")
+	DefaultLevels.Define("info", LevelInfo, "inf")
+	DefaultLevels.Define("warn", LevelWarn, "warning", "wrn")
+	DefaultLevels.Define("error", LevelError, "eror", "err")
+	DefaultLevels.Define("fatal", LevelFatal, "fatl", "panic", "dpanic", "critical", "crit")
+}
+
+// String implements fmt.Stringer.
+func (l Level) String() string {
+	if s, ok := DefaultLevels.Name(l); ok {
+		return s
+	}
+	return "unknown"
+}
+
+// ParseLevel parses a level name case-insensitively using DefaultLevels.
+// Common aliases such as warning and err are recognized.
+func ParseLevel(s string) (Level, bool) {
+	return DefaultLevels.Parse(s)
+}
+
+// NormalizeLevel replaces the value of the level and levl fields
+// by the canonical name of the level.
+func NormalizeLevel() Option {
+	return BeforeWrite(func(e *Entry) bool {
+		for i := range e.Fields {
+			if f := &e.Fields[i]; isLevelKey(f.Key) {
+				if level, ok := ParseLevel(f.Value.String()); ok {
+					f.Value = StringValue(level.String())
+				}
+			}
+		}
+		return true
+	})
+}
+
+func isLevelKey(key string) bool {
+	return key == "level" || key == "levl"
+}
+
+// lineString finds the value of a string field of a JSON encoded entry without decoding it.
+func lineString(line []byte, key string) (string, bool) {
+	if i := bytes.Index(line, []byte(`"`+key+`":"`)); i != -1 {
+		s := line[i+len(key)+4:]
+		if j := bytes.IndexByte(s, '"'); j != -1 {
+			return zcstring(s[:j]), true
+		}
+	}
+	return "", false
+}
+
+// lineLevel finds the level of a JSON encoded entry without decoding it.
+func lineLevel(line []byte) (Level, bool) {
+	for _, key := range [...]string{"levl", "level"} {
+		if s, ok := lineString(line, key); ok {
+			return ParseLevel(s)
+		}
+	}
+	return 0, false
+}
+
+// textLevel finds the level of an unparsed log message.
+func textLevel(s string) (Level, bool) {
+	for len(s) > 0 {
+		var key, val string
+		var ok bool
+		if s, key, val, _, ok = scanKeyVals(s); ok && isLevelKey(key) {
+			return ParseLevel(val)
+		}
+	}
+	return 0, false
+}
+
+// priority returns the syslog priority of the level. It is derived from the
+// value of the level, so that levels defined in between the predefined levels
+// get the severities in between: the upper half of the info range is notice,
+// and every step of ten above LevelFatal raises the severity to alert and emergency.
+func (l Level) priority() int {
+	var p int
+	switch {
+	case l < LevelInfo:
+		return 7
+	case l < LevelWarn:
+		p = 6 - int(l-LevelInfo)/5
+	default:
+		p = 4 - int(l-LevelWarn)/10
+	}
+	if p < 0 {
+		return 0
+	}
+	return p
+}
+
+// entryLevel finds the level of an entry in its fields,
+// falling back to scanning the unparsed log line.
+func entryLevel(e *Entry, text string) (Level, bool) {
+	for _, f := range e.Fields {
+		if isLevelKey(f.Key) {
+			return ParseLevel(f.Value.String())
+		}
+	}
+	return textLevel(text)
+}
+
+func appendPriority(dst []byte, e *Entry, text string) []byte {
+	level, ok := entryLevel(e, text)
+	if !ok {
+		level = LevelInfo
+	}
+	return append(dst, '<', byte('0'+level.priority()), '>')
+}
+
+// DefaultLevelKeywords is the default keyword table of DetectLevel.
+var DefaultLevelKeywords = map[string]Level{
+	"panic":      LevelFatal,
+	"fatal":      LevelFatal,
+	"error":      LevelError,
+	"failed":     LevelError,
+	"failure":    LevelError,
+	"warning":    LevelWarn,
+	"warn":       LevelWarn,
+	"deprecated": LevelWarn,
+	"debug":      LevelDebug,
+}
+
+// keywordLevel returns the most severe level of the keywords found in s.
+func keywordLevel(s string, keywords map[string]Level) (level Level, ok bool) {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, w := range words {
+		if l, found := keywords[strings.ToLower(w)]; found && (!ok || l > level) {
+			level, ok = l, true
+		}
+	}
+	return
+}
+
+// DetectLevel infers the level of entries that have no level or levl field
+// from the keywords in the message and stores it in the levl field.
+// The keywords map lower case words to levels, and the most severe level wins.
+// Entries without any keywords are given the info level.
+// DefaultLevelKeywords is used if keywords is nil.
+func DetectLevel(keywords map[string]Level) Option {
+	if keywords == nil {
+		keywords = DefaultLevelKeywords
+	}
+	return BeforeWrite(func(e *Entry) bool {
+		if _, ok := entryLevel(e, e.Message); !ok {
+			level, ok := keywordLevel(e.Message, keywords)
+			if !ok {
+				level = LevelInfo
+			}
+			e.Set("levl", StringValue(level.String()))
+		}
+		return true
+	})
+}
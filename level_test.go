@@ -0,0 +1,91 @@
+package slog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLpriority(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lpriority|Lmessage)
+	l.Println("level=error oops")
+	l.Println("no level")
+	l.Println("levl=debug detail")
+	l.Println("levl=trace detail")
+	l.Println("level=panic boom")
+
+	exp := "<3>{\"mesg\":\"level=error oops\"}\n<6>{\"mesg\":\"no level\"}\n<7>{\"mesg\":\"levl=debug detail\"}\n" +
+		"<7>{\"mesg\":\"levl=trace detail\"}\n<2>{\"mesg\":\"level=panic boom\"}\n"
+	if b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
+
+func TestLevelPriority(t *testing.T) {
+	for level, exp := range map[Level]int{
+		LevelTrace: 7, LevelDebug: 7, LevelInfo: 6, 25: 5, LevelWarn: 4, 35: 4,
+		LevelError: 3, LevelFatal: 2, 60: 1, 70: 0, 100: 0,
+	} {
+		if p := level.priority(); p != exp {
+			t.Error(level, p, exp)
+		}
+	}
+}
+
+func TestTraceFatal(t *testing.T) {
+	var level LevelVar
+	level.Set(LevelTrace)
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields, MinLevel(&level), NormalizeLevel())
+	l.Print("levl=TRACE a=1")
+	l.Print("level=crit a=2")
+	level.Set(LevelFatal)
+	l.Print("levl=error a=3")
+	l.Print("levl=dpanic a=4")
+
+	exp := "{\"levl\":\"trace\",\"a\":1}\n{\"level\":\"fatal\",\"a\":2}\n{\"levl\":\"fatal\",\"a\":4}\n"
+	if b.String() != exp {
+		t.Fatal(b.String())
+	}
+	if !(LevelTrace < LevelDebug && LevelFatal > LevelError) {
+		t.Fatal("order")
+	}
+}
+
+func TestDetectLevel(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lmessage, DetectLevel(nil))
+	l.Println("connection Failed: error reading header")
+	l.Println("retrying, warning")
+	l.Println("level=debug explicit error")
+	l.Println("all good")
+
+	exp := "{\"mesg\":\"connection Failed: error reading header\",\"levl\":\"error\"}\n" +
+		"{\"mesg\":\"retrying, warning\",\"levl\":\"warn\"}\n" +
+		"{\"mesg\":\"level=debug explicit error\"}\n" +
+		"{\"mesg\":\"all good\",\"levl\":\"info\"}\n"
+	if b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
+
+func TestLevelTable(t *testing.T) {
+	levels := NewLevelTable()
+	levels.Define("notice", 25, "NOTE")
+	if l, ok := levels.Parse("note"); !ok || l != 25 {
+		t.Fatal(l, ok)
+	} else if name, _ := levels.Name(25); name != "notice" {
+		t.Fatal(name)
+	} else if _, ok := levels.Parse("info"); ok {
+		t.Fatal()
+	}
+}
+
+func TestNormalizeLevel(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields|Lpriority, NormalizeLevel())
+	l.Println("level=WARNING disk almost full")
+	if exp := "<4>{\"level\":\"warn\"}\n"; b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
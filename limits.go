@@ -0,0 +1,85 @@
+package slog
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxFields limits the number of fields of an entry to n, protecting downstream
+// systems from messages with thousands of key-value pairs. If collapse is true,
+// the excess fields are collapsed into the _extra field as a string of key=value
+// pairs. Otherwise they are dropped and their number is stored in the _dropped field.
+// The message is left intact. Register it before other hooks that add fields.
+func MaxFields(n int, collapse bool) Option {
+	if n < 0 {
+		n = 0
+	}
+	return BeforeWrite(func(e *Entry) bool {
+		if len(e.Fields) <= n {
+			return true
+		}
+		excess := e.Fields[n:]
+		var marker Field
+		if collapse {
+			var b strings.Builder
+			for i, f := range excess {
+				if i > 0 {
+					b.WriteByte(' ')
+				}
+				b.WriteString(f.Key)
+				b.WriteByte('=')
+				b.WriteString(quoteValue(f.Value.String()))
+			}
+			marker = Field{"_extra", StringValue(b.String())}
+		} else {
+			marker = Field{"_dropped", IntValue(int64(len(excess)))}
+		}
+		e.Fields = append(e.Fields[:n], marker)
+		return true
+	})
+}
+
+// TruncateValues truncates the string values of the fields that are longer
+// than n bytes to n bytes followed by an ellipsis, so that a single giant value
+// does not balloon the entry. The value in the message is truncated as well.
+// The keys of the truncated fields are listed in the _truncated field,
+// for example "_truncated":["body"]. Register it before SignHMAC and HashChain,
+// so that the signature covers the _truncated field.
+func TruncateValues(n int) Option {
+	return func(l *logwriter) {
+		var truncated []string
+		l.before = append(l.before, func(e *Entry) bool {
+			truncated = truncated[:0]
+			for i := range e.Fields {
+				f := &e.Fields[i]
+				if f.Value.Kind() != KindString || len(f.Value.String()) <= n {
+					continue
+				}
+				s := f.Value.String()
+				cut := n
+				for cut > 0 && !utf8.RuneStart(s[cut]) {
+					cut--
+				}
+				val := StringValue(s[:cut] + "...")
+				e.Message = replaceFieldText(e.Message, f.Key, f.Value, val)
+				f.Value = val
+				truncated = append(truncated, f.Key)
+			}
+			return true
+		})
+		l.seals = append(l.seals, func(dst []byte, start int) []byte {
+			if len(truncated) == 0 {
+				return dst
+			}
+			dst = append(sealComma(dst), `"_truncated":[`...)
+			for i, key := range truncated {
+				if i > 0 {
+					dst = append(dst, ',')
+				}
+				dst = l.quote(dst, key)
+			}
+			truncated = truncated[:0]
+			return append(dst, ']')
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package slog
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestMaxFields(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields, MaxFields(2, true))
+	l.Print("a=1 b=2 c=3 d=\"x y\"")
+	l.Print("a=1 b=2")
+	if want := `{"a":1,"b":2,"_extra":"c=3 d=\"x y\""}` + "\n" + `{"a":1,"b":2}` + "\n"; b.String() != want {
+		t.Fatal(b.String())
+	}
+
+	b.Reset()
+	l = New(&b, "", Lparsefields, MaxFields(1, false))
+	l.Print("a=1 b=2 c=3")
+	if want := `{"a":1,"_dropped":2}` + "\n"; b.String() != want {
+		t.Fatal(b.String())
+	}
+}
+
+func TestTruncateValues(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields|Lmessage, TruncateValues(4))
+	l.Print("a=abcdefgh b=abcd c=123456 d=\"héééé\"")
+	l.Print("a=abc")
+	if want := `{"mesg":"a=abcd... b=abcd c=123456 d=\"hé...\"","a":"abcd...","b":"abcd","c":123456,"d":"hé...","_truncated":["a","d"]}` + "\n" +
+		`{"mesg":"a=abc","a":"abc"}` + "\n"; b.String() != want {
+		t.Fatal(b.String())
+	}
+
+	e := Entry{Fields: []Field{{"<é>", StringValue("abcdefgh")}}}
+	line := NewEncoder(0, TruncateValues(4), ASCIIOnly(), HTMLSafe()).AppendEntry(nil, &e)
+	if want := `{"\u003c\u00e9\u003e":"abcd...","_truncated":["\u003c\u00e9\u003e"]}` + "\n"; string(line) != want || !json.Valid(line) {
+		t.Fatal(string(line))
+	}
+}
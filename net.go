@@ -0,0 +1,92 @@
+package slog
+
+import (
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+)
+
+// NetOptions configures a network writer.
+type NetOptions struct {
+	// TLS enables TLS if not nil. The client certificates for mutual TLS
+	// are given in TLS.Certificates. The server name for SNI and verification
+	// defaults to the host of the address.
+	TLS *tls.Config
+
+	// Timeout limits the duration of dialing and of each write.
+	// Defaults to ten seconds.
+	Timeout time.Duration
+}
+
+// NetWriter writes entries to a network connection, such as a remote syslog
+// daemon or log collector. A connection that fails is closed and dialed again
+// by the next write. It is safe for concurrent use.
+type NetWriter struct {
+	network string
+	addr    string
+	opts    NetOptions
+	mu      sync.Mutex
+	conn    net.Conn
+}
+
+// Dial creates a writer that sends entries to the address on the named network,
+// such as tcp or unix, optionally over TLS. It returns an error if the first
+// connection cannot be established.
+//
+//	cert, _ := tls.LoadX509KeyPair("client.crt", "client.key")
+//	w, err := slog.Dial("tcp", "logs.example.com:6514", slog.NetOptions{
+//		TLS: &tls.Config{Certificates: []tls.Certificate{cert}},
+//	})
+//	l := slog.New(slog.NewSyslogWriter(w, slog.SyslogOptions{}), "", slog.LstdFlags)
+func Dial(network, addr string, opts NetOptions) (*NetWriter, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	w := &NetWriter{network: network, addr: addr, opts: opts}
+	if err := w.dial(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *NetWriter) dial() (err error) {
+	d := &net.Dialer{Timeout: w.opts.Timeout}
+	if w.opts.TLS != nil {
+		w.conn, err = tls.DialWithDialer(d, w.network, w.addr, w.opts.TLS)
+	} else {
+		w.conn, err = d.Dial(w.network, w.addr)
+	}
+	return err
+}
+
+// Write implements io.Writer.
+func (w *NetWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.conn == nil {
+		if err := w.dial(); err != nil {
+			w.conn = nil
+			return 0, err
+		}
+	}
+	_ = w.conn.SetWriteDeadline(time.Now().Add(w.opts.Timeout))
+	n, err := w.conn.Write(p)
+	if err != nil {
+		w.conn.Close()
+		w.conn = nil
+	}
+	return n, err
+}
+
+// Close closes the connection.
+func (w *NetWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
@@ -0,0 +1,117 @@
+package slog
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDialTLS(t *testing.T) {
+	// borrow the certificate of a test server, which is valid for example.com
+	srv := httptest.NewTLSServer(nil)
+	defer srv.Close()
+
+	config := srv.TLS.Clone()
+	config.ClientAuth = tls.RequireAnyClientCert
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	type result struct {
+		line       string
+		serverName string
+		peers      int
+	}
+	results := make(chan result, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		state := conn.(*tls.Conn).ConnectionState()
+		results <- result{line, state.ServerName, len(state.PeerCertificates)}
+	}()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	w, err := Dial("tcp", ln.Addr().String(), NetOptions{TLS: &tls.Config{
+		RootCAs:      roots,
+		ServerName:   "example.com",
+		Certificates: srv.TLS.Certificates,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	New(w, "", Lmessage).Print("hello")
+	r := <-results
+	if r.line != `{"mesg":"hello"}`+"\n" || r.serverName != "example.com" || r.peers != 1 {
+		t.Fatal(r)
+	}
+}
+
+func TestDialRedial(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	lines := make(chan string, 2)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			line, _ := bufio.NewReader(conn).ReadString('\n')
+			lines <- line
+			conn.Close()
+		}
+	}()
+
+	w, err := Dial("tcp", ln.Addr().String(), NetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	if _, err := w.Write([]byte("a\n")); err != nil {
+		t.Fatal(err)
+	}
+	if s := <-lines; s != "a\n" {
+		t.Fatal(s)
+	}
+
+	// the server closed the connection, so the writer redials after a failed write
+	for i := 0; i < 100; i++ {
+		if _, err := w.Write([]byte("b\n")); err != nil {
+			continue
+		}
+		select {
+		case s := <-lines:
+			if s != "b\n" {
+				t.Fatal(s)
+			}
+			return
+		default:
+		}
+	}
+}
+
+func TestDialError(t *testing.T) {
+	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := ln.Addr().String()
+	ln.Close()
+	if _, err := Dial("tcp", addr, NetOptions{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,206 @@
+package slog
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Option configures a structured writer.
+type Option func(*logwriter)
+
+// fieldNames are the names of the fields that slog extracts from the log line.
+type fieldNames struct {
+	prefix   string
+	time     string
+	file     string
+	line     string
+	function string
+	message  string
+	raw      string
+	text     string
+}
+
+var defaultNames = fieldNames{
+	prefix:   "prfx",
+	time:     "time",
+	file:     "fnam",
+	line:     "flno",
+	function: "func",
+	message:  "mesg",
+	raw:      "raw",
+	text:     "text",
+}
+
+const (
+	colorAuto = iota
+	colorForce
+	colorNever
+)
+
+// ForceColor colorizes the output even if it is not a terminal
+// or flag Lcolor is not set.
+func ForceColor() Option {
+	return func(l *logwriter) {
+		l.colorMode = colorForce
+	}
+}
+
+// NoColor disables colors even if flag Lcolor is set and the output is a terminal.
+func NoColor() Option {
+	return func(l *logwriter) {
+		l.colorMode = colorNever
+	}
+}
+
+// Logstash formats entries according to the Logstash v1 JSON event format.
+// The time is stored in the @timestamp field, the message in the message field
+// and a @version field is added. Combine it with log.LUTC to produce valid timestamps.
+func Logstash() Option {
+	return func(l *logwriter) {
+		l.names.time = "@timestamp"
+		l.names.message = "message"
+		l.header = `"@version":"1"`
+	}
+}
+
+// Hook inspects or modifies an entry before it is written.
+// Hooks may add, rename and remove fields. The strings of the entry are only
+// valid for the duration of the call and must be copied if they are retained.
+// The entry is dropped if the hook returns false.
+type Hook func(e *Entry) bool
+
+// BeforeWrite registers hooks that are called in order after a log line
+// has been parsed and before it is encoded and written.
+func BeforeWrite(hooks ...Hook) Option {
+	return func(l *logwriter) {
+		l.before = append(l.before, hooks...)
+	}
+}
+
+// AfterWrite registers hooks that are called in order after an entry
+// has been written, together with the error returned by the output writer.
+// The entry must not be modified.
+func AfterWrite(hooks ...func(e *Entry, err error)) Option {
+	return func(l *logwriter) {
+		l.after = append(l.after, hooks...)
+	}
+}
+
+// sealFunc appends fields computed over the encoded entry dst[start:],
+// which is missing the closing brace.
+type sealFunc func(dst []byte, start int) []byte
+
+// BufferSize sets the initial capacity of the buffer that entries are encoded into,
+// which defaults to 256 bytes. The buffer grows to fit large entries.
+// If max is positive, a buffer that has grown beyond max bytes is released
+// after the entry has been written, so that a single oversized entry does not
+// retain memory for the lifetime of the writer.
+func BufferSize(initial, max int) Option {
+	return func(l *logwriter) {
+		if initial > 0 {
+			l.initBuf = initial
+		}
+		l.maxBuf = max
+	}
+}
+
+// OmitStructuredMessage omits the mesg field of entries whose message
+// consists solely of key-value pairs, because the fields already carry
+// all information. It requires Lparsefields.
+func OmitStructuredMessage() Option {
+	return func(l *logwriter) {
+		l.omitStructured = true
+	}
+}
+
+// SplitMessage replaces the mesg field by the text field, which holds only
+// the free text of the message that is not part of a key-value pair,
+// so that the pairs are not duplicated. For example, the message
+// "request failed code=500" produces {"text":"request failed","code":500}.
+// The text field is omitted if the message has no free text. It requires Lparsefields.
+func SplitMessage() Option {
+	return func(l *logwriter) {
+		l.splitText = true
+	}
+}
+
+// TimeLayout sets the layout of the time field stamped by flag Ltimestamp,
+// as understood by time.Time.Format, such as time.RFC3339 or time.StampMilli.
+func TimeLayout(layout string) Option {
+	return func(l *logwriter) {
+		l.timeLayout = layout
+	}
+}
+
+// FloatFormat sets the format and precision of floating point field values,
+// as understood by strconv.FormatFloat. The default format 'f' with precision -1
+// prints the shortest decimal that round-trips, which can be very long for
+// large and small numbers. Format 'g' with precision -1 uses the exponent form
+// for those instead. The formats 'b' and 'x' are not valid JSON and are ignored.
+func FloatFormat(fmt byte, prec int) Option {
+	return func(l *logwriter) {
+		switch fmt {
+		case 'e', 'E', 'f', 'g', 'G':
+			l.floatFmt, l.floatPrec = fmt, prec
+		}
+	}
+}
+
+// maxSafeInt is the largest integer that a float64 represents exactly.
+const maxSafeInt = 1 << 53
+
+// SafeIntegers encodes integer field values whose magnitude exceeds 2^53 as strings,
+// because consumers that read JSON numbers as float64, such as JavaScript, silently
+// lose precision beyond that. Unsigned values that exceed the range of int64,
+// such as uint64 identifiers, are always encoded as strings.
+func SafeIntegers() Option {
+	return func(l *logwriter) {
+		l.safeInts = true
+	}
+}
+
+// hasUnquotedField reports whether mesg has the field key=val with an unquoted value.
+func hasUnquotedField(mesg, key, val string) bool {
+	for s := mesg; len(s) > 0; {
+		z, k, v, quote, ok := scanKeyVals(s)
+		if ok && k == key && v == val && !quote {
+			return true
+		}
+		s = z
+	}
+	return false
+}
+
+// NumericLeadingZeros parses unquoted decimal numbers with leading zeros,
+// such as 007, as decimal integers. By default they are kept as strings, because they are typically
+// zip codes or identifiers whose zeros are significant.
+func NumericLeadingZeros() Option {
+	return BeforeWrite(func(e *Entry) bool {
+		for i := range e.Fields {
+			f := &e.Fields[i]
+			// only values that appear unquoted in the message are parsed
+			if f.Value.kind == KindString && hasLeadingZero(f.Value.str) && hasUnquotedField(e.Message, f.Key, f.Value.str) {
+				if n, err := strconv.ParseInt(f.Value.str, 10, 64); err == nil {
+					f.Value = IntValue(n)
+				}
+			}
+		}
+		return true
+	})
+}
+
+// PrefixFields parses the key-value pairs in the prefix of the logger, such as
+// "[job=sync] ", into fields that are added to every entry. Surrounding spaces
+// and punctuation marks are ignored. The prefix is never parsed for fields otherwise.
+func PrefixFields() Option {
+	return func(l *logwriter) {
+		text := strings.TrimFunc(l.prefix, isSpaceOrPunct)
+		for len(text) > 0 {
+			var key, val string
+			var quote, ok bool
+			if text, key, val, quote, ok = scanKeyVals(text); ok {
+				l.static = append(l.static, Field{key, parseValue(val, quote)})
+			}
+		}
+	}
+}
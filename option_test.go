@@ -0,0 +1,206 @@
+package slog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogstash(t *testing.T) {
+	var event struct {
+		Timestamp time.Time `json:"@timestamp"`
+		Version   string    `json:"@version"`
+		Message   string    `json:"message"`
+		Status    int       `json:"status"`
+	}
+
+	var b bytes.Buffer
+	l := New(&b, "", log.LstdFlags|log.LUTC|Lmessage|Lparsefields, Logstash())
+	l.Println("request status=200")
+
+	if err := json.Unmarshal(b.Bytes(), &event); err != nil {
+		t.Fatal(err)
+	} else if event.Timestamp.IsZero() || event.Version != "1" {
+		t.Fatal(b.String())
+	} else if event.Message != "request status=200" || event.Status != 200 {
+		t.Fatal(b.String())
+	}
+}
+
+func TestHooks(t *testing.T) {
+	var b bytes.Buffer
+	var written int
+
+	l := New(&b, "", Lparsefields, BeforeWrite(
+		func(e *Entry) bool {
+			return e.Message != "drop me"
+		},
+		func(e *Entry) bool {
+			e.Set("tenant", StringValue("acme"))
+			e.Rename("usr", "user")
+			e.Delete("noise")
+			return true
+		},
+	), AfterWrite(func(e *Entry, err error) {
+		if err == nil && e.Message == "usr=bob noise=1 a=2 noise=3" {
+			written++
+		}
+	}))
+
+	l.Println("usr=bob noise=1 a=2 noise=3")
+	l.Println("drop me")
+
+	if exp := "{\"user\":\"bob\",\"a\":2,\"tenant\":\"acme\"}\n"; b.String() != exp {
+		t.Fatal(b.String())
+	} else if written != 1 {
+		t.Fatal(written)
+	}
+}
+
+func TestBufferSize(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lmessage, BufferSize(16, 64))
+	lw := l.Writer().(*logwriter)
+	if cap(lw.buf) != 16 {
+		t.Fatal(cap(lw.buf))
+	}
+
+	l.Println(strings.Repeat("x", 100))
+	if cap(lw.buf) != 16 {
+		t.Fatal(cap(lw.buf))
+	}
+
+	l.Println("small")
+	if cap(lw.buf) < 16 || cap(lw.buf) > 64 {
+		t.Fatal(cap(lw.buf))
+	}
+}
+
+func TestOmitStructuredMessage(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lmessage|Lparsefields, OmitStructuredMessage())
+	l.Println("event=login user=bob")
+	l.Println("user=bob logged in")
+	l.Println("just text")
+
+	exp := "{\"event\":\"login\",\"user\":\"bob\"}\n" +
+		"{\"mesg\":\"user=bob logged in\",\"user\":\"bob\"}\n" +
+		"{\"mesg\":\"just text\"}\n"
+	if b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
+
+func TestSplitMessage(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lmessage|Lparsefields, SplitMessage())
+	l.Println("request failed code=500")
+	l.Println("code=200")
+
+	exp := "{\"text\":\"request failed\",\"code\":500}\n{\"code\":200}\n"
+	if b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
+
+func TestFloatFormat(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields, FloatFormat('g', -1))
+	l.Println("a=123456789012345678901234.5 b=0.000000001 c=1.5")
+	l = New(&b, "", Lparsefields, FloatFormat('f', 2))
+	l.Println("a=3.14159")
+
+	exp := "{\"a\":1.2345678901234569e+23,\"b\":1e-09,\"c\":1.5}\n{\"a\":3.14}\n"
+	if b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
+
+func TestSafeIntegers(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields, SafeIntegers())
+	l.Println("a=9007199254740992 b=9007199254740993 c=-9007199254740993 d=18446744073709551615")
+
+	exp := "{\"a\":9007199254740992,\"b\":\"9007199254740993\",\"c\":\"-9007199254740993\",\"d\":\"18446744073709551615\"}\n"
+	if b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
+
+func TestLeadingZeros(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields)
+	l.Println("zip=02134 code=007 zero=0 neg=-01 hex=0x1f")
+	l = New(&b, "", Lparsefields, NumericLeadingZeros())
+	l.Println("code=007 zip=\"02134\" id=08")
+	l.Println("xid=007 id=\"007\" n=\"x code=01\" code=\"01\"")
+
+	exp := "{\"zip\":\"02134\",\"code\":\"007\",\"zero\":0,\"neg\":\"-01\",\"hex\":31}\n" +
+		"{\"code\":7,\"zip\":\"02134\",\"id\":8}\n" +
+		"{\"xid\":7,\"id\":\"007\",\"n\":\"x code=01\",\"code\":\"01\"}\n"
+	if b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
+
+type unwrapWriter struct{ io.Writer }
+
+func (w unwrapWriter) Unwrap() io.Writer { return w.Writer }
+
+type fakeTerm struct{ bytes.Buffer }
+
+type fakeTermInfo struct{ os.FileInfo }
+
+func (fakeTermInfo) Mode() os.FileMode { return os.ModeCharDevice }
+
+func (*fakeTerm) Stat() (os.FileInfo, error) { return fakeTermInfo{}, nil }
+
+func TestColorDetection(t *testing.T) {
+	var term fakeTerm
+	if !isterm(unwrapWriter{&term}) {
+		t.Error("unwrapped tty not detected")
+	}
+	if !isterm(fanout{&term, unwrapWriter{&term}}) {
+		t.Error("fanout tty not detected")
+	}
+	if isterm(fanout{&term, &bytes.Buffer{}}) {
+		t.Error("fanout non-tty detected")
+	}
+
+	defer setenv("NO_COLOR", "")()
+	l := New(unwrapWriter{&term}, "", Lcolor|Lmessage, NoColor())
+	l.Print("hello")
+	if strings.Contains(term.String(), "\033") {
+		t.Error("NoColor did not disable colors")
+	}
+
+	var b bytes.Buffer
+	l = New(&b, "", Lmessage, ForceColor())
+	l.Print("hello")
+	if !strings.Contains(b.String(), "\033") {
+		t.Error("ForceColor did not enable colors")
+	}
+}
+
+func TestLtimestamp(t *testing.T) {
+	clock := fixedClock(time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC))
+
+	var b bytes.Buffer
+	l := New(&b, "", Ltimestamp|Lmessage|log.LUTC, WithClock(clock))
+	l.Print("hello")
+	if exp := `{"time":"2024-05-06T07:08:09.123456789Z","mesg":"hello"}` + "\n"; b.String() != exp {
+		t.Fatal(b.String())
+	}
+
+	b.Reset()
+	l = New(&b, "", Ltimestamp|Lmessage|log.LUTC, WithClock(clock), TimeLayout(time.RFC3339))
+	l.Print("hello")
+	if exp := `{"time":"2024-05-06T07:08:09Z","mesg":"hello"}` + "\n"; b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
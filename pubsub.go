@@ -0,0 +1,77 @@
+package slog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// PubSubPublisher publishes a message to a Google Cloud Pub/Sub topic.
+// It is implemented by a thin wrapper around the Publish method of the
+// Pub/Sub client library, so that slog does not depend on it.
+type PubSubPublisher interface {
+	Publish(ctx context.Context, data []byte, attrs map[string]string, orderingKey string) error
+}
+
+// PubSubOptions configures a Pub/Sub writer.
+type PubSubOptions struct {
+	// Attributes lists the fields that are copied to the message attributes.
+	Attributes []string
+
+	// OrderingKey is the name of the field whose value is used
+	// as the ordering key of the message. Messages are unordered if empty.
+	OrderingKey string
+
+	// Timeout limits the duration of each Publish call.
+	// Defaults to ten seconds.
+	Timeout time.Duration
+}
+
+type pubsubwriter struct {
+	pub  PubSubPublisher
+	opts PubSubOptions
+}
+
+func (w *pubsubwriter) Write(p []byte) (int, error) {
+	var attrs map[string]string
+	var key string
+
+	if len(w.opts.Attributes) > 0 || w.opts.OrderingKey != "" {
+		var fields map[string]interface{}
+		if err := json.Unmarshal(p, &fields); err != nil {
+			return 0, err
+		}
+
+		for _, name := range w.opts.Attributes {
+			if val, ok := fields[name]; ok {
+				if attrs == nil {
+					attrs = make(map[string]string, len(w.opts.Attributes))
+				}
+				attrs[name] = fmt.Sprint(val)
+			}
+		}
+
+		if val, ok := fields[w.opts.OrderingKey]; ok {
+			key = fmt.Sprint(val)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.opts.Timeout)
+	defer cancel()
+
+	data := append([]byte(nil), p...)
+	if err := w.pub.Publish(ctx, data, attrs, key); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// NewPubSubWriter creates a writer that publishes each entry as a message to a Pub/Sub topic.
+func NewPubSubWriter(pub PubSubPublisher, opts PubSubOptions) io.Writer {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	return &pubsubwriter{pub, opts}
+}
@@ -0,0 +1,36 @@
+package slog
+
+import (
+	"context"
+	"testing"
+)
+
+type pubsubRecorder struct {
+	data  string
+	attrs map[string]string
+	key   string
+}
+
+func (r *pubsubRecorder) Publish(_ context.Context, data []byte, attrs map[string]string, key string) error {
+	r.data, r.attrs, r.key = string(data), attrs, key
+	return nil
+}
+
+func TestPubSubWriter(t *testing.T) {
+	var rec pubsubRecorder
+	w := NewPubSubWriter(&rec, PubSubOptions{
+		Attributes:  []string{"level", "status"},
+		OrderingKey: "user",
+	})
+
+	l := New(w, "", Lparsefields)
+	l.Println("level=info user=alice status=200")
+
+	if rec.data != "{\"level\":\"info\",\"user\":\"alice\",\"status\":200}\n" {
+		t.Fatal(rec.data)
+	} else if rec.attrs["level"] != "info" || rec.attrs["status"] != "200" || len(rec.attrs) != 2 {
+		t.Fatal(rec.attrs)
+	} else if rec.key != "alice" {
+		t.Fatal(rec.key)
+	}
+}
@@ -0,0 +1,43 @@
+package slog
+
+import "strings"
+
+// quoteValue quotes a value so that it is parsed back as a single field value.
+// The scanner does not support escapes, so double quotes are replaced by single quotes.
+func quoteValue(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n\v\f\r\"=") {
+		return s
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `'`) + `"`
+}
+
+// Q formats v as a field value that is parsed back as a single value of the same type.
+// Values containing spaces, double quotes or equals signs are quoted,
+// and double quotes are replaced by single quotes because the parser does not
+// support escapes. Strings that would be parsed as numbers, booleans or null are quoted
+// to keep them strings.
+//
+//	log.Printf("user=%s", slog.Q(name))
+func Q(v interface{}) string {
+	val := AnyValue(v)
+	s := val.String()
+	if val.Kind() == KindString && parseValue(s, false).Kind() != KindString {
+		return `"` + s + `"`
+	}
+	return quoteValue(s)
+}
+
+// KV formats a key-value pair that is parsed back as a single field.
+// Spaces and equals signs in the key are replaced by underscores,
+// and the value is formatted by Q.
+//
+//	log.Print("login ", slog.KV("user", name), " ", slog.KV("ok", true))
+func KV(key string, v interface{}) string {
+	key = strings.Map(func(r rune) rune {
+		if r == '=' || r == '"' || strings.ContainsRune(" \t\n\v\f\r", r) {
+			return '_'
+		}
+		return r
+	}, key)
+	return key + "=" + Q(v)
+}
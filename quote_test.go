@@ -0,0 +1,41 @@
+package slog
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestQ(t *testing.T) {
+	for _, tt := range []struct {
+		v    interface{}
+		want string
+		kind Kind
+	}{
+		{"alice", "alice", KindString},
+		{"alice smith", `"alice smith"`, KindString},
+		{`say "hi"`, `"say 'hi'"`, KindString},
+		{"a=b", `"a=b"`, KindString},
+		{"", `""`, KindString},
+		{"123", `"123"`, KindString},
+		{"true", `"true"`, KindString},
+		{42, "42", KindInt},
+		{2.5, "2.5", KindFloat},
+		{false, "false", KindBool},
+		{nil, "null", KindNull},
+		{errors.New("not found"), `"not found"`, KindString},
+	} {
+		got := KV("k", tt.v)
+		if got != "k="+tt.want {
+			t.Errorf("%v: got %s, want k=%s", tt.v, got, tt.want)
+			continue
+		}
+		e, _ := Parse(got, "", Lparsefields)
+		if len(e.Fields) != 1 || e.Fields[0].Value.Kind() != tt.kind {
+			t.Errorf("%v: parsed as %v", tt.v, e.Fields)
+		}
+	}
+
+	if got := KV("a key=", 1); got != "a_key_=1" {
+		t.Error(got)
+	}
+}
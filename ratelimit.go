@@ -0,0 +1,121 @@
+package slog
+
+import (
+	"sync"
+	"time"
+)
+
+// bucket is a token bucket rate limiter.
+type bucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(every time.Duration, burst int) *bucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &bucket{
+		rate:   1 / every.Seconds(),
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// allow reports whether a token is available at time now and consumes it.
+func (b *bucket) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// RateLimitOptions configures RateLimit.
+type RateLimitOptions struct {
+	// Key returns the key of the bucket that an entry draws from.
+	// Entries with an empty key are not limited. Defaults to KeyMessage.
+	Key func(e *Entry) string
+
+	// Every is the interval at which a bucket gains a token.
+	Every time.Duration
+
+	// Burst is the capacity of a bucket. Defaults to 1.
+	Burst int
+
+	// MaxKeys bounds the number of buckets. All buckets are reset
+	// when a new key would exceed it. Defaults to 10000.
+	MaxKeys int
+
+	// Clock tells the time used to refill the buckets. Defaults to SystemClock.
+	Clock Clock
+}
+
+// KeyMessage keys entries by the free text of the message, which excludes
+// the key-value pairs, so that entries of the same message template share a bucket.
+func KeyMessage(e *Entry) string {
+	return freeText(e.Message)
+}
+
+// KeyPrefix keys entries by their prefix.
+func KeyPrefix(e *Entry) string {
+	return e.Prefix
+}
+
+// KeyField keys entries by the value of a field, such as path.
+func KeyField(key string) func(e *Entry) string {
+	return func(e *Entry) string {
+		if v, ok := e.Get(key); ok {
+			return v.String()
+		}
+		return ""
+	}
+}
+
+// RateLimit drops the entries that exceed the rate of their key, such that every
+// key has its own token bucket that allows a burst of entries and refills at a
+// steady rate. A noisy endpoint can thus be throttled without affecting other entries:
+//
+//	slog.RateLimit(slog.RateLimitOptions{Key: slog.KeyField("path"), Every: time.Second, Burst: 10})
+func RateLimit(opts RateLimitOptions) Option {
+	if opts.Key == nil {
+		opts.Key = KeyMessage
+	}
+	if opts.MaxKeys <= 0 {
+		opts.MaxKeys = 10000
+	}
+	clock := clockOrSystem(opts.Clock)
+
+	var mu sync.Mutex
+	buckets := map[string]*bucket{}
+	return BeforeWrite(func(e *Entry) bool {
+		key := opts.Key(e)
+		if key == "" {
+			return true
+		}
+		mu.Lock()
+		b := buckets[key]
+		if b == nil {
+			if len(buckets) >= opts.MaxKeys {
+				buckets = map[string]*bucket{}
+			}
+			b = newBucket(opts.Every, opts.Burst)
+			// copy the key, which may refer to the log line
+			buckets[string(append([]byte(nil), key...))] = b
+		}
+		mu.Unlock()
+		return b.allow(clock.Now())
+	})
+}
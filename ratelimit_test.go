@@ -0,0 +1,35 @@
+package slog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	var b bytes.Buffer
+	clock := fixedClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	l := New(&b, "", Lparsefields, RateLimit(RateLimitOptions{
+		Key:   KeyField("path"),
+		Every: time.Second,
+		Burst: 2,
+		Clock: clock,
+	}))
+
+	for i := 0; i < 5; i++ {
+		l.Print("path=/noisy")
+		l.Print("path=/quiet")
+	}
+	l.Print("no path")
+
+	if n := strings.Count(b.String(), "/noisy"); n != 2 {
+		t.Fatal(b.String())
+	}
+	if n := strings.Count(b.String(), "/quiet"); n != 2 {
+		t.Fatal(b.String())
+	}
+	if !strings.HasSuffix(b.String(), "{}\n") {
+		t.Fatal(b.String())
+	}
+}
@@ -0,0 +1,42 @@
+package slog
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+type logreader struct {
+	r   *bufio.Reader
+	lw  *logwriter
+	out bytes.Buffer
+	err error
+}
+
+func (r *logreader) Read(p []byte) (int, error) {
+	for r.out.Len() == 0 && r.err == nil {
+		line, err := r.r.ReadBytes('\n')
+		if len(line) > 0 {
+			if _, werr := r.lw.Write(line); werr != nil {
+				err = werr
+			}
+		}
+		r.err = err
+	}
+
+	if r.out.Len() > 0 {
+		return r.out.Read(p)
+	}
+	return 0, r.err
+}
+
+// NewReader creates a reader that converts the output of a standard logger
+// with the given prefix and flags, read from r, to structured logs.
+// Lines that do not match the prefix and flags are passed through as the message.
+// Flags Lcolor and Lfuncname are ignored.
+func NewReader(r io.Reader, prefix string, flags int, opts ...Option) io.Reader {
+	var lr logreader
+	lr.r = bufio.NewReader(r)
+	lr.lw = newLogwriter(&lr.out, prefix, flags&^(Lcolor|Lfuncname), opts)
+	return &lr
+}
@@ -0,0 +1,30 @@
+package slog
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestNewReader(t *testing.T) {
+	var b bytes.Buffer
+	l := log.New(&b, "app: ", log.LstdFlags|log.LUTC)
+	l.Println("hello a=1")
+	b.WriteString("not a log line")
+
+	res, err := io.ReadAll(NewReader(&b, "app: ", log.LstdFlags|log.LUTC|Lparsefields|Lmessage))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(string(res), "\n")
+	if len(lines) != 3 || lines[2] != "" {
+		t.Fatal(string(res))
+	} else if !strings.HasPrefix(lines[0], "{\"prfx\":\"app\",\"time\":") || !strings.HasSuffix(lines[0], "\"mesg\":\"hello a=1\",\"a\":1}") {
+		t.Fatal(lines[0])
+	} else if lines[1] != "{\"mesg\":\"not a log line\"}" {
+		t.Fatal(lines[1])
+	}
+}
@@ -0,0 +1,48 @@
+package slog
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Patterns of personally identifiable information for use with RedactPatterns.
+var (
+	PatternEmail = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	PatternIPv4  = regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4][0-9]|1?[0-9]?[0-9])\.){3}(?:25[0-5]|2[0-4][0-9]|1?[0-9]?[0-9])\b`)
+	PatternSSN   = regexp.MustCompile(`\b[0-9]{3}-[0-9]{2}-[0-9]{4}\b`)
+)
+
+// RedactPatterns replaces all matches of the patterns in the message, the prefix,
+// the raw line stored by Lraw and the string values of the fields by the replacement.
+// The patterns are combined into a single regular expression,
+// so that every string is scanned only once.
+func RedactPatterns(replacement string, patterns ...*regexp.Regexp) Option {
+	if len(patterns) == 0 {
+		return func(*logwriter) {}
+	}
+
+	exprs := make([]string, len(patterns))
+	for i, p := range patterns {
+		exprs[i] = "(?:" + p.String() + ")"
+	}
+	re := regexp.MustCompile(strings.Join(exprs, "|"))
+
+	redact := func(s string) string {
+		if re.FindStringIndex(s) == nil {
+			return s
+		}
+		return re.ReplaceAllLiteralString(s, replacement)
+	}
+
+	return BeforeWrite(func(e *Entry) bool {
+		e.Message = redact(e.Message)
+		e.Prefix = redact(e.Prefix)
+		e.Raw = redact(e.Raw)
+		for i := range e.Fields {
+			if f := &e.Fields[i]; f.Value.Kind() == KindString {
+				f.Value = StringValue(redact(f.Value.String()))
+			}
+		}
+		return true
+	})
+}
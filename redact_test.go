@@ -0,0 +1,40 @@
+package slog
+
+import (
+	"bytes"
+	"log"
+	"testing"
+)
+
+func TestRedactPatterns(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields|Lmessage, RedactPatterns("[redacted]", PatternEmail, PatternIPv4, PatternSSN))
+	l.Println("login user=bob@example.com from ip=10.0.0.1 ssn=\"123-45-6789\" attempts=3")
+
+	exp := "{\"mesg\":\"login user=[redacted] from ip=[redacted] ssn=\\\"[redacted]\\\" attempts=3\"," +
+		"\"user\":\"[redacted]\",\"ip\":\"[redacted]\",\"ssn\":\"[redacted]\",\"attempts\":3}\n"
+	if b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
+
+func TestRedactPatternsRaw(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "10.0.0.1: ", Lraw, RedactPatterns("x", PatternIPv4))
+	l.Println("from 10.0.0.2")
+
+	if exp := "{\"prfx\":\"x\",\"raw\":\"x: from x\"}\n"; b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
+
+func BenchmarkSlogRedactPatterns(b *testing.B) {
+	buf := bytes.NewBuffer(make([]byte, 0, 2<<20))
+	l := New(buf, "test: ", log.Ldate|log.Ltime|log.LUTC|log.Lmicroseconds|log.Lshortfile|Lparsefields,
+		RedactPatterns("[redacted]", PatternEmail, PatternIPv4, PatternSSN))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Println("a=\"hello world\" b=1337 c=true d=3.14 e=/index.html f=<nil>")
+	}
+	b.StopTimer()
+}
@@ -0,0 +1,101 @@
+package slog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"time"
+)
+
+// ReplayOptions configures Replay.
+type ReplayOptions struct {
+	// Speed is the factor by which the original pace of the entries is accelerated,
+	// such that 1 replays them at the original pace and 10 replays them ten times
+	// as fast. The entries are written as fast as possible if zero.
+	Speed float64
+
+	// Clock tells the time used to pace the entries and measure the elapsed time.
+	// Defaults to SystemClock.
+	Clock Clock
+}
+
+// ReplayStats summarizes a replay.
+type ReplayStats struct {
+	// Entries is the number of entries written.
+	Entries int64
+
+	// Bytes is the number of bytes written.
+	Bytes int64
+
+	// Elapsed is the duration of the replay.
+	Elapsed time.Duration
+}
+
+// replayTime returns the time stamp of a recorded entry.
+func replayTime(line []byte) (time.Time, bool) {
+	for _, key := range []string{"time", "@timestamp"} {
+		if s, ok := lineString(line, key); ok {
+			if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// Replay reads the entries recorded in r and writes them to w one line at a time,
+// paced by their time stamps according to opts.Speed. It is meant to benchmark
+// sinks, rotation and asynchronous writers with realistic data.
+// Entries without a time stamp are written immediately.
+// Replay stops at the end of r, at the first write error or when ctx is done.
+func Replay(ctx context.Context, w io.Writer, r io.Reader, opts ReplayOptions) (ReplayStats, error) {
+	clock := clockOrSystem(opts.Clock)
+	start := clock.Now()
+
+	var stats ReplayStats
+	var first time.Time
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			if line[len(line)-1] != '\n' {
+				line = append(line, '\n')
+			}
+
+			if t, ok := replayTime(line); ok && opts.Speed > 0 {
+				if first.IsZero() {
+					first = t
+				}
+				due := time.Duration(float64(t.Sub(first)) / opts.Speed)
+				if wait := due - clock.Now().Sub(start); wait > 0 {
+					if err := sleepContext(ctx, wait); err != nil {
+						stats.Elapsed = clock.Now().Sub(start)
+						return stats, err
+					}
+				}
+			}
+
+			if ctx.Err() != nil {
+				stats.Elapsed = clock.Now().Sub(start)
+				return stats, ctx.Err()
+			}
+			n, werr := w.Write(line)
+			stats.Bytes += int64(n)
+			if werr != nil {
+				stats.Elapsed = clock.Now().Sub(start)
+				return stats, werr
+			}
+			stats.Entries++
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			stats.Elapsed = clock.Now().Sub(start)
+			return stats, err
+		}
+	}
+
+	stats.Elapsed = clock.Now().Sub(start)
+	return stats, nil
+}
@@ -0,0 +1,35 @@
+package slog
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReplay(t *testing.T) {
+	input := `{"time":"2024-01-02T03:04:05Z","a":1}` + "\n" +
+		"\n" +
+		`{"a":2}` + "\n" +
+		`{"time":"2024-01-02T03:04:05.2Z","a":3}`
+
+	var b bytes.Buffer
+	start := time.Now()
+	stats, err := Replay(context.Background(), &b, strings.NewReader(input), ReplayOptions{Speed: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Fatal("not paced", elapsed)
+	}
+	if stats.Entries != 3 || stats.Bytes != int64(b.Len()) || strings.Count(b.String(), "\n") != 3 {
+		t.Fatal(stats, b.String())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Replay(ctx, &b, strings.NewReader(input), ReplayOptions{}); err != context.Canceled {
+		t.Fatal(err)
+	}
+}
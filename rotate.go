@@ -0,0 +1,274 @@
+package slog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RotateOptions configures a RotatingWriter.
+type RotateOptions struct {
+	// FileOptions configures the durability of the active file.
+	FileOptions
+
+	// MaxSize rotates the active file before it grows beyond the number of bytes.
+	MaxSize int64
+
+	// Interval rotates the active file when it is older than the interval.
+	Interval time.Duration
+
+	// MaxTotalSize deletes the oldest rotated files when the rotated files and
+	// the active file together exceed the number of bytes, independently of the
+	// other limits. The size is checked when the writer is opened and rotated.
+	// The active file is never deleted. Rotated files are kept if zero.
+	MaxTotalSize int64
+
+	// Template is the name of the files in the directory of the path, with
+	// strftime directives such as app-%Y%m%d-%H.log, or a Go time layout such as
+	// app-2006-01-02.log if it does not contain a percent sign. The directives
+	// %Y, %y, %m, %d, %H, %I, %M, %S, %j, %a, %b, %p, %z, %Z and %% are supported.
+	// A name that is already in use is given a counter before the extension,
+	// such as app-20240102-15.1.log. Defaults to the base name of the path with
+	// the time of creation inserted before the extension, as in app-20240102T150405.log.
+	Template string
+
+	// UTC formats the time stamps of the file names in UTC instead of local time.
+	// Names in local time repeat an hour when daylight saving time ends,
+	// in which case the files of the repeated hour are given counters.
+	UTC bool
+
+	// Clock tells the time of the rotations and the file names. Defaults to SystemClock.
+	Clock Clock
+}
+
+// RotatingWriter appends entries to a file that is replaced by a new file when
+// it reaches the size or age limit. The files are named after the time of their
+// creation, such as app-20240102T150405.log for the path app.log, and the path
+// itself is a symbolic link to the active file, so that tail -F and collectors always
+// find the live file. The link is replaced atomically on rotation.
+// It is safe for concurrent use.
+type RotatingWriter struct {
+	path  string
+	opts  RotateOptions
+	clock Clock
+	tmpl  *nameTemplate
+
+	mu     sync.Mutex
+	fw     *FileWriter
+	name   string
+	size   int64
+	opened time.Time
+	closed bool
+}
+
+// OpenRotating opens a rotating writer for the path. The file that the link at
+// the path points to is appended to, if any. A regular file at the path is renamed
+// like a rotated file first.
+func OpenRotating(path string, opts RotateOptions) (*RotatingWriter, error) {
+	if opts.Template == "" {
+		opts.Template = defaultNameTemplate(path)
+	}
+	tmpl, err := parseNameTemplate(opts.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	rw := &RotatingWriter{path: path, opts: opts, clock: clockOrSystem(opts.Clock), tmpl: tmpl}
+	now := rw.clock.Now()
+
+	fi, err := os.Lstat(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, err
+	case fi.Mode()&os.ModeSymlink != 0:
+		if target, err := os.Readlink(path); err == nil {
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(filepath.Dir(path), target)
+			}
+			if fi, err := os.Stat(target); err == nil && fi.Mode().IsRegular() {
+				if rw.fw, err = OpenFile(target, opts.FileOptions); err != nil {
+					return nil, err
+				}
+				rw.name, rw.size, rw.opened = target, fi.Size(), now
+				rw.prune()
+				return rw, nil
+			}
+		}
+	case fi.Mode().IsRegular():
+		if err := os.Rename(path, rw.fileName(fi.ModTime())); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := rw.create(now); err != nil {
+		return nil, err
+	}
+	return rw, nil
+}
+
+// fileName returns a name for a file created at t that is not in use.
+func (rw *RotatingWriter) fileName(t time.Time) string {
+	if rw.opts.UTC {
+		t = t.UTC()
+	} else {
+		t = t.Local()
+	}
+	dir := filepath.Dir(rw.path)
+	for i := 0; ; i++ {
+		name := filepath.Join(dir, rw.tmpl.format(t, i))
+		if _, err := os.Lstat(name); os.IsNotExist(err) {
+			return name
+		}
+	}
+}
+
+// create opens a new active file and points the link at it.
+func (rw *RotatingWriter) create(now time.Time) error {
+	name := rw.fileName(now)
+	fw, err := OpenFile(name, rw.opts.FileOptions)
+	if err != nil {
+		return err
+	}
+	if err := rw.link(name); err != nil {
+		fw.Close()
+		return err
+	}
+	rw.fw, rw.name, rw.size, rw.opened = fw, name, 0, now
+	rw.prune()
+	return nil
+}
+
+// archives returns the rotated files, oldest first.
+func (rw *RotatingWriter) archives() ([]os.FileInfo, error) {
+	dir := filepath.Dir(rw.path)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]os.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !rw.tmpl.match(entry.Name()) || filepath.Join(dir, entry.Name()) == rw.name {
+			continue
+		}
+		if fi, err := entry.Info(); err == nil {
+			files = append(files, fi)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if !files[i].ModTime().Equal(files[j].ModTime()) {
+			return files[i].ModTime().Before(files[j].ModTime())
+		}
+		return files[i].Name() < files[j].Name()
+	})
+	return files, nil
+}
+
+// prune deletes the oldest rotated files until the total size fits MaxTotalSize.
+// Errors are ignored, because the files are tried again on the next rotation.
+func (rw *RotatingWriter) prune() {
+	if rw.opts.MaxTotalSize <= 0 {
+		return
+	}
+	files, err := rw.archives()
+	if err != nil {
+		return
+	}
+	total := rw.size
+	for _, fi := range files {
+		total += fi.Size()
+	}
+	dir := filepath.Dir(rw.path)
+	for _, fi := range files {
+		if total <= rw.opts.MaxTotalSize {
+			return
+		}
+		if os.Remove(filepath.Join(dir, fi.Name())) == nil {
+			total -= fi.Size()
+		}
+	}
+}
+
+// link atomically replaces the link at the path with a relative link to name.
+func (rw *RotatingWriter) link(name string) error {
+	tmp := rw.path + ".tmp"
+	_ = os.Remove(tmp)
+	if err := os.Symlink(filepath.Base(name), tmp); err != nil {
+		return err
+	}
+	return os.Rename(tmp, rw.path)
+}
+
+func (rw *RotatingWriter) rotateLocked(now time.Time) error {
+	if rw.fw != nil {
+		err := rw.fw.Close()
+		rw.fw = nil
+		if err != nil {
+			return err
+		}
+	}
+	return rw.create(now)
+}
+
+// Write appends an entry to the active file, rotating it first if the entry
+// would exceed the size limit or the file exceeds the age limit.
+func (rw *RotatingWriter) Write(p []byte) (int, error) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	if rw.closed {
+		return 0, os.ErrClosed
+	}
+
+	now := rw.clock.Now()
+	rotate := rw.fw == nil
+	if rw.size > 0 {
+		rotate = rotate || rw.opts.MaxSize > 0 && rw.size+int64(len(p)) > rw.opts.MaxSize
+		rotate = rotate || rw.opts.Interval > 0 && now.Sub(rw.opened) >= rw.opts.Interval
+	}
+	if rotate {
+		if err := rw.rotateLocked(now); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := rw.fw.Write(p)
+	rw.size += int64(n)
+	return n, err
+}
+
+// Rotate replaces the active file by a new file.
+func (rw *RotatingWriter) Rotate() error {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	return rw.rotateLocked(rw.clock.Now())
+}
+
+// Name returns the name of the active file.
+func (rw *RotatingWriter) Name() string {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	return rw.name
+}
+
+// Close syncs and closes the active file.
+func (rw *RotatingWriter) Close() error {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	rw.closed = true
+	if rw.fw == nil {
+		return nil
+	}
+	err := rw.fw.Close()
+	rw.fw = nil
+	return err
+}
+
+// Drain is like Close, but gives up waiting for the file to be synced
+// when the context is done.
+func (rw *RotatingWriter) Drain(ctx context.Context) error {
+	return drainFunc(ctx, rw.Close)
+}
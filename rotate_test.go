@@ -0,0 +1,184 @@
+package slog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func readLink(t *testing.T, path string) string {
+	t.Helper()
+	target, err := os.Readlink(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return target
+}
+
+func readFile(t *testing.T, name string) string {
+	t.Helper()
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestRotatingWriter(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	if err := os.WriteFile(path, []byte("old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+	_ = os.Chtimes(path, modTime, modTime)
+
+	clock := &stepClock{t: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)}
+	rw, err := OpenRotating(path, RotateOptions{MaxSize: 8, UTC: true, Clock: clock})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the regular file is renamed like a rotated file
+	if s := readFile(t, filepath.Join(dir, "app-20231231T000000.log")); s != "old\n" {
+		t.Fatal(s)
+	}
+	if s := readLink(t, path); s != "app-20240102T150405.log" {
+		t.Fatal(s)
+	}
+
+	_, _ = rw.Write([]byte("a=1\n"))
+	_, _ = rw.Write([]byte("a=2\n"))
+	// rotates in the same second
+	_, _ = rw.Write([]byte("a=3\n"))
+	if s := readLink(t, path); s != "app-20240102T150405.1.log" || rw.Name() != filepath.Join(dir, s) {
+		t.Fatal(s, rw.Name())
+	}
+	if s := readFile(t, filepath.Join(dir, "app-20240102T150405.log")); s != "a=1\na=2\n" {
+		t.Fatal(s)
+	}
+	if err := rw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rw.Write([]byte("x\n")); err != os.ErrClosed {
+		t.Fatal(err)
+	}
+
+	// reopening appends to the active file
+	rw, err = OpenRotating(path, RotateOptions{Interval: time.Hour, UTC: true, Clock: clock})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = rw.Write([]byte("a=4\n"))
+	if s := readFile(t, path); s != "a=3\na=4\n" {
+		t.Fatal(s)
+	}
+
+	clock.t = clock.t.Add(time.Hour)
+	_, _ = rw.Write([]byte("a=5\n"))
+	if s := readLink(t, path); s != "app-20240102T160405.log" {
+		t.Fatal(s)
+	}
+	if err := rw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRotatingWriterMaxTotalSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	other := filepath.Join(dir, "app-server.log")
+	_ = os.WriteFile(other, []byte("keep\n"), 0644)
+
+	clock := &stepClock{t: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)}
+	rw, err := OpenRotating(path, RotateOptions{MaxSize: 4, MaxTotalSize: 10, UTC: true, Clock: clock})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rw.Close()
+
+	for _, line := range []string{"a=1\n", "a=2\n", "a=3\n", "a=4\n"} {
+		clock.t = clock.t.Add(time.Minute)
+		_, _ = rw.Write([]byte(line))
+		_ = os.Chtimes(rw.Name(), clock.t, clock.t)
+	}
+
+	// the total size is checked when a file is created, before it is written to
+	names, _ := filepath.Glob(filepath.Join(dir, "app-2*.log"))
+	if len(names) != 3 {
+		t.Fatal(names)
+	}
+	if s := readFile(t, names[0]); s != "a=2\n" {
+		t.Fatal(s)
+	}
+	if s := readFile(t, other); s != "keep\n" {
+		t.Fatal(s)
+	}
+}
+
+func TestNameTemplate(t *testing.T) {
+	tm := time.Date(2024, 3, 9, 7, 8, 9, 0, time.UTC)
+	for _, tt := range []struct {
+		template, name, counted string
+		match, nomatch          []string
+	}{
+		{"app-%Y%m%d-%H.log", "app-20240309-07.log", "app-20240309-07.2.log",
+			[]string{"app-20231231-23.log"}, []string{"app-server.log", "app-20240309-07.log.gz", "app.log"}},
+		{"100%%-%b-%j", "100%-Mar-069", "100%-Mar-069.2", nil, []string{"100%-Mar-69"}},
+		{"app-2006-01-02.log", "app-2024-03-09.log", "app-2024-03-09.2.log",
+			[]string{"app-2023-12-31.log"}, []string{"app-server.log", "app-2024-03-09.x.log"}},
+	} {
+		tmpl, err := parseNameTemplate(tt.template)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := tmpl.format(tm, 0); s != tt.name {
+			t.Fatal(tt.template, s)
+		}
+		if s := tmpl.format(tm, 2); s != tt.counted {
+			t.Fatal(tt.template, s)
+		}
+		for _, name := range append([]string{tt.name, tt.counted}, tt.match...) {
+			if !tmpl.match(name) {
+				t.Fatal(tt.template, name)
+			}
+		}
+		for _, name := range tt.nomatch {
+			if tmpl.match(name) {
+				t.Fatal(tt.template, name)
+			}
+		}
+	}
+
+	for _, s := range []string{"", "logs/app-%Y.log", "app-%Q.log", "app-%"} {
+		if _, err := parseNameTemplate(s); err == nil {
+			t.Fatal(s)
+		}
+	}
+}
+
+func TestRotatingWriterTemplate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	// the time zone of the clock does not matter with UTC
+	clock := &stepClock{t: time.Date(2024, 11, 3, 1, 30, 0, 0, time.FixedZone("EDT", -4*3600))}
+	rw, err := OpenRotating(path, RotateOptions{Template: "app-%Y%m%d-%H.log", UTC: true, Clock: clock})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rw.Close()
+	if s := readLink(t, path); s != "app-20241103-05.log" {
+		t.Fatal(s)
+	}
+
+	// rotating twice within the hour, as happens when a local hour repeats
+	clock.t = clock.t.Add(10 * time.Minute)
+	if err := rw.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	if s := readLink(t, path); s != "app-20241103-05.1.log" {
+		t.Fatal(s)
+	}
+}
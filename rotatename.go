@@ -0,0 +1,146 @@
+package slog
+
+import (
+	"errors"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// strftimeDirectives maps the supported strftime directives to the equivalent
+// Go layouts and to regular expressions of their values.
+var strftimeDirectives = map[byte][2]string{
+	'Y': {"2006", "[0-9]{4}"},
+	'y': {"06", "[0-9]{2}"},
+	'm': {"01", "[0-9]{2}"},
+	'd': {"02", "[0-9]{2}"},
+	'H': {"15", "[0-9]{2}"},
+	'I': {"03", "[0-9]{2}"},
+	'M': {"04", "[0-9]{2}"},
+	'S': {"05", "[0-9]{2}"},
+	'j': {"002", "[0-9]{3}"},
+	'a': {"Mon", "[A-Za-z]{3}"},
+	'b': {"Jan", "[A-Za-z]{3}"},
+	'p': {"PM", "[AP]M"},
+	'z': {"-0700", "[-+][0-9]{4}"},
+	'Z': {"MST", "[-+A-Za-z0-9]+"},
+}
+
+// templatePart is literal text or a Go layout.
+type templatePart struct {
+	lit    string
+	layout string
+}
+
+// nameTemplate formats and recognizes the names of rotated files.
+type nameTemplate struct {
+	ext    string         // extension, before which counters are inserted
+	parts  []templatePart // strftime template without the extension
+	re     *regexp.Regexp // matches names of a strftime template
+	layout string         // Go layout, if not a strftime template
+}
+
+var counterSuffix = regexp.MustCompile(`\.[0-9]+$`)
+
+// parseNameTemplate parses a template with strftime directives,
+// or a Go layout if it does not contain a percent sign.
+func parseNameTemplate(s string) (*nameTemplate, error) {
+	if s == "" || strings.ContainsAny(s, `/\`) {
+		return nil, errors.New("slog: invalid file name template " + strconv.Quote(s))
+	}
+	t := &nameTemplate{ext: filepath.Ext(s)}
+	if strings.ContainsAny(t.ext, "%0123456789") {
+		t.ext = ""
+	}
+	if !strings.Contains(s, "%") {
+		t.layout = s
+		return t, nil
+	}
+
+	body := strings.TrimSuffix(s, t.ext)
+	var re strings.Builder
+	re.WriteByte('^')
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if c != '%' {
+			j := strings.IndexByte(body[i:], '%')
+			if j == -1 {
+				j = len(body) - i
+			}
+			lit := body[i : i+j]
+			t.parts = append(t.parts, templatePart{lit: lit})
+			re.WriteString(regexp.QuoteMeta(lit))
+			i += j - 1
+			continue
+		}
+		if i++; i == len(body) {
+			return nil, errors.New("slog: file name template ends with %")
+		}
+		if body[i] == '%' {
+			t.parts = append(t.parts, templatePart{lit: "%"})
+			re.WriteString("%")
+			continue
+		}
+		d, ok := strftimeDirectives[body[i]]
+		if !ok {
+			return nil, errors.New("slog: unknown directive %" + string(body[i]) + " in file name template")
+		}
+		t.parts = append(t.parts, templatePart{layout: d[0]})
+		re.WriteString(d[1])
+	}
+	re.WriteString(`(\.[0-9]+)?`)
+	re.WriteString(regexp.QuoteMeta(t.ext))
+	re.WriteByte('$')
+	t.re = regexp.MustCompile(re.String())
+	return t, nil
+}
+
+// format returns the name of a file created at tm with the counter n, if not zero.
+func (t *nameTemplate) format(tm time.Time, n int) string {
+	var name string
+	if t.layout != "" {
+		name = strings.TrimSuffix(tm.Format(t.layout), t.ext)
+	} else {
+		var b strings.Builder
+		for _, p := range t.parts {
+			if p.layout != "" {
+				b.WriteString(tm.Format(p.layout))
+			} else {
+				b.WriteString(p.lit)
+			}
+		}
+		name = b.String()
+	}
+	if n > 0 {
+		name += "." + strconv.Itoa(n)
+	}
+	return name + t.ext
+}
+
+// match reports whether the base name could have been produced by the template.
+func (t *nameTemplate) match(name string) bool {
+	if t.re != nil {
+		return t.re.MatchString(name)
+	}
+	if _, err := time.Parse(t.layout, name); err == nil {
+		return true
+	}
+	body := strings.TrimSuffix(name, t.ext)
+	if len(body) == len(name) && t.ext != "" {
+		return false
+	}
+	if loc := counterSuffix.FindStringIndex(body); loc != nil {
+		_, err := time.Parse(t.layout, body[:loc[0]]+t.ext)
+		return err == nil
+	}
+	return false
+}
+
+// defaultNameTemplate inserts the time of creation before the extension of the path.
+func defaultNameTemplate(path string) string {
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	return strings.ReplaceAll(strings.TrimSuffix(base, ext), "%", "%%") + "-%Y%m%dT%H%M%S" + ext
+}
@@ -0,0 +1,32 @@
+package slog
+
+import "io"
+
+type levelRouter struct {
+	level     Level
+	low, high io.Writer
+}
+
+func (r *levelRouter) Write(p []byte) (int, error) {
+	return r.route(lineLevel(p)).Write(p)
+}
+
+// route returns the writer of the level.
+func (r *levelRouter) route(level Level, ok bool) io.Writer {
+	if ok && level >= r.level {
+		return r.high
+	}
+	return r.low
+}
+
+// SplitByLevel creates a writer that sends entries at or above the level to high
+// and all other entries, including those without a level, to low.
+// A logger that writes directly to it routes on the parsed level of the entry,
+// so colored lines are routed correctly. The writer does not report
+// the writers it wraps, so colors are never enabled automatically.
+// A typical use is to send errors to stderr and everything else to stdout:
+//
+//	logger := slog.New(slog.SplitByLevel(slog.LevelError, os.Stdout, os.Stderr), "", slog.LstdFlags)
+func SplitByLevel(level Level, low, high io.Writer) io.Writer {
+	return &levelRouter{level, low, high}
+}
@@ -0,0 +1,37 @@
+package slog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSplitByLevel(t *testing.T) {
+	var low, high bytes.Buffer
+	l := New(SplitByLevel(LevelWarn, &low, &high), "", Lparsefields)
+	l.Println("level=info a=1")
+	l.Println("level=error a=2")
+	l.Println("level=warning a=3")
+	l.Println("a=4")
+
+	if low.String() != "{\"level\":\"info\",\"a\":1}\n{\"a\":4}\n" {
+		t.Fatal(low.String())
+	} else if high.String() != "{\"level\":\"error\",\"a\":2}\n{\"level\":\"warning\",\"a\":3}\n" {
+		t.Fatal(high.String())
+	}
+}
+
+func TestSplitByLevelColor(t *testing.T) {
+	var low, high bytes.Buffer
+	l := New(SplitByLevel(LevelError, &low, &high), "", Lparsefields, ForceColor())
+	l.Println("level=error a=1")
+	l.Println("level=info a=2")
+
+	if high.Len() == 0 || !bytes.Contains(high.Bytes(), []byte("error")) {
+		t.Fatalf("high: %q", high.String())
+	} else if low.Len() == 0 || bytes.Contains(low.Bytes(), []byte("error")) {
+		t.Fatalf("low: %q", low.String())
+	}
+	if isterm(SplitByLevel(LevelError, &fakeTerm{}, &fakeTerm{})) {
+		t.Error("split tty detected")
+	}
+}
@@ -0,0 +1,112 @@
+package slog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ObjectUploader uploads an object to S3 or any S3-compatible object store.
+// It is implemented by a thin wrapper around the PutObject operation of
+// the client library of the store, so that slog does not depend on it.
+type ObjectUploader interface {
+	Upload(ctx context.Context, key string, body io.Reader) error
+}
+
+// S3Options configures an S3 archiving writer.
+type S3Options struct {
+	// Key is a text/template that produces the object key.
+	// The template is executed with a value that has the fields
+	// Time (time.Time), Host (string) and Seq (int).
+	// Defaults to DefaultS3Key.
+	Key string
+
+	// Size is the uncompressed size in bytes at which an object is uploaded.
+	// Defaults to 64 MiB.
+	Size int
+
+	// Interval is the maximum time that entries are buffered.
+	// Defaults to five minutes.
+	Interval time.Duration
+
+	// Timeout limits the duration of each upload.
+	// Defaults to one minute.
+	Timeout time.Duration
+
+	// Clock tells the time used in the object keys. Defaults to SystemClock.
+	Clock Clock
+}
+
+// DefaultS3Key is the default object key template of the S3 writer.
+const DefaultS3Key = `{{.Time.Format "2006/01/02"}}/{{.Host}}-{{.Time.Format "20060102T150405Z"}}-{{.Seq}}.ndjson.gz`
+
+type s3key struct {
+	Time time.Time
+	Host string
+	Seq  int
+}
+
+type s3writer struct {
+	up   ObjectUploader
+	opts S3Options
+	key  *template.Template
+	host string
+	seq  int
+}
+
+func (w *s3writer) upload(recs [][]byte) error {
+	var body bytes.Buffer
+	zw := gzip.NewWriter(&body)
+	for _, rec := range recs {
+		_, _ = zw.Write(rec)
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	var key strings.Builder
+	w.seq++
+	if err := w.key.Execute(&key, s3key{clockOrSystem(w.opts.Clock).Now().UTC(), w.host, w.seq}); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.opts.Timeout)
+	defer cancel()
+	return w.up.Upload(ctx, key.String(), &body)
+}
+
+// NewS3Writer creates a writer that accumulates entries into gzip compressed
+// NDJSON objects and uploads them when the size or time threshold is reached.
+// It panics if the key template cannot be parsed.
+// Errors of asynchronous uploads are returned by the next call to Write or Close.
+// Close must be called to upload the last object.
+func NewS3Writer(up ObjectUploader, opts S3Options) io.WriteCloser {
+	if opts.Key == "" {
+		opts.Key = DefaultS3Key
+	}
+	if opts.Size <= 0 {
+		opts.Size = 64 << 20
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 5 * time.Minute
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = time.Minute
+	}
+
+	host, _ := os.Hostname()
+	w := &s3writer{
+		up:   up,
+		opts: opts,
+		key:  template.Must(template.New("key").Parse(opts.Key)),
+		host: host,
+	}
+
+	return newBatcher(math.MaxInt32, opts.Size, math.MaxInt32, opts.Interval, w.upload)
+}
@@ -0,0 +1,46 @@
+package slog
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"testing"
+)
+
+type uploadRecorder map[string]string
+
+func (u uploadRecorder) Upload(_ context.Context, key string, body io.Reader) error {
+	zr, err := gzip.NewReader(body)
+	if err != nil {
+		return err
+	}
+	b, err := io.ReadAll(zr)
+	u[key] = string(b)
+	return err
+}
+
+func TestS3Writer(t *testing.T) {
+	rec := uploadRecorder{}
+	w := NewS3Writer(rec, S3Options{
+		Key:  "logs/{{.Host}}/{{.Seq}}.gz",
+		Size: 48,
+	})
+
+	l := New(w, "", Lmessage)
+	l.Println("first entry")
+	l.Println("second entry")
+	l.Println("third entry")
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	host, _ := os.Hostname()
+	if len(rec) != 2 {
+		t.Fatal(rec)
+	} else if rec["logs/"+host+"/1.gz"] != "{\"mesg\":\"first entry\"}\n{\"mesg\":\"second entry\"}\n" {
+		t.Fatal(rec)
+	} else if rec["logs/"+host+"/2.gz"] != "{\"mesg\":\"third entry\"}\n" {
+		t.Fatal(rec)
+	}
+}
@@ -0,0 +1,159 @@
+package slog
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type sinkLatency struct {
+	writes uint64
+	total  time.Duration
+}
+
+// Stats measures the throughput of writers configured with CountStats
+// and the latency of their sinks, so that operators have visibility into
+// the health of logging without an external metrics system.
+// It is safe for concurrent use.
+type Stats struct {
+	drops *DropCounter
+	clock Clock
+
+	mu        sync.Mutex
+	levels    map[Level]uint64
+	bytes     uint64
+	lastDrops uint64
+	sinks     map[string]*sinkLatency
+}
+
+// NewStats creates a new Stats that also reports the entries counted by drops,
+// which may be nil.
+func NewStats(drops *DropCounter) *Stats {
+	return &Stats{
+		drops:  drops,
+		clock:  SystemClock,
+		levels: map[Level]uint64{},
+		sinks:  map[string]*sinkLatency{},
+	}
+}
+
+// CountStats counts the entries and bytes written by the writer in s,
+// and measures the latency of its output writer as the sink named out.
+func CountStats(s *Stats) Option {
+	return func(l *logwriter) {
+		l.stats = s
+	}
+}
+
+func (s *Stats) addEntry(level Level, n int) {
+	s.mu.Lock()
+	s.levels[level]++
+	s.bytes += uint64(n)
+	s.mu.Unlock()
+}
+
+func (s *Stats) addLatency(name string, d time.Duration) {
+	s.mu.Lock()
+	sl := s.sinks[name]
+	if sl == nil {
+		sl = &sinkLatency{}
+		s.sinks[name] = sl
+	}
+	sl.writes++
+	sl.total += d
+	s.mu.Unlock()
+}
+
+type statsSink struct {
+	s    *Stats
+	name string
+	w    io.Writer
+}
+
+func (w *statsSink) Write(p []byte) (int, error) {
+	start := w.s.clock.Now()
+	n, err := w.w.Write(p)
+	w.s.addLatency(w.name, w.s.clock.Now().Sub(start))
+	return n, err
+}
+
+func (w *statsSink) Unwrap() io.Writer { return w.w }
+
+// Sink returns a writer that measures the latency of writes to w as the named sink.
+//
+//	stats := slog.NewStats(nil)
+//	w := io.MultiWriter(stats.Sink("stderr", os.Stderr), stats.Sink("file", f))
+//	l := slog.New(w, "", slog.LstdFlags, slog.CountStats(stats))
+func (s *Stats) Sink(name string, w io.Writer) io.Writer {
+	return &statsSink{s, name, w}
+}
+
+// Report logs a summary entry to l every interval, of the form
+// "logging stats entries=120 bytes=9600 drops=3 period=1m0s eps.info=1.833 eps.error=0.167 latms.out=0.042",
+// with the number of entries, bytes and dropped entries in the last interval,
+// the entries per second by level, where entries without a level are counted
+// as none, and the mean write latency in milliseconds by sink.
+// The returned function stops reporting.
+func (s *Stats) Report(l *log.Logger, every time.Duration) (stop func()) {
+	done := make(chan struct{})
+	var once sync.Once
+	go func() {
+		t := time.NewTicker(every)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				s.report(l, every)
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() { once.Do(func() { close(done) }) }
+}
+
+func (s *Stats) report(l *log.Logger, every time.Duration) {
+	var drops uint64
+	if s.drops != nil {
+		drops = s.drops.Total()
+	}
+
+	s.mu.Lock()
+	levels, sinks, bytes := s.levels, s.sinks, s.bytes
+	s.levels, s.sinks, s.bytes = map[Level]uint64{}, map[string]*sinkLatency{}, 0
+	drops, s.lastDrops = drops-s.lastDrops, drops
+	s.mu.Unlock()
+
+	keys := make([]Level, 0, len(levels))
+	var entries uint64
+	for level, n := range levels {
+		keys = append(keys, level)
+		entries += n
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	names := make([]string, 0, len(sinks))
+	for name := range sinks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "logging stats entries=%d bytes=%d drops=%d period=%s", entries, bytes, drops, every)
+	for _, level := range keys {
+		name := "none"
+		if level != 0 {
+			name = level.String()
+		}
+		fmt.Fprintf(&b, " eps.%s=%.3f", name, float64(levels[level])/every.Seconds())
+	}
+	for _, name := range names {
+		sl := sinks[name]
+		fmt.Fprintf(&b, " latms.%s=%.3f", name, float64(sl.total)/float64(sl.writes)/float64(time.Millisecond))
+	}
+	l.Print(b.String())
+}
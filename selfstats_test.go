@@ -0,0 +1,57 @@
+package slog
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type stepClock struct {
+	t    time.Time
+	step time.Duration
+}
+
+func (c *stepClock) Now() time.Time {
+	c.t = c.t.Add(c.step)
+	return c.t
+}
+
+func TestStats(t *testing.T) {
+	drops := NewDropCounter()
+	stats := NewStats(drops)
+	stats.clock = &stepClock{step: time.Millisecond}
+
+	var b bytes.Buffer
+	l := New(stats.Sink("buf", &b), "", Lparsefields, CountStats(stats), CountDrops(drops),
+		BeforeWrite(func(e *Entry) bool {
+			v, _ := e.Get("level")
+			return v.String() != "debug"
+		}))
+
+	l.Println("level=info a=1")
+	l.Println("level=info a=2")
+	l.Println("level=error a=3")
+	l.Println("level=debug a=4")
+	l.Println("hello")
+
+	var r bytes.Buffer
+	stats.report(log.New(&r, "", 0), 10*time.Second)
+	exp := "logging stats entries=4 bytes=" + strconv.Itoa(b.Len()) + " drops=1 period=10s" +
+		" eps.none=0.100 eps.info=0.200 eps.error=0.100 latms.buf=1.000 latms.out=3.000\n"
+	if r.String() != exp {
+		t.Fatal(r.String())
+	}
+
+	r.Reset()
+	stats.report(log.New(&r, "", 0), 10*time.Second)
+	if r.String() != "logging stats entries=0 bytes=0 drops=0 period=10s\n" {
+		t.Fatal(r.String())
+	}
+
+	stop := stats.Report(log.New(io.Discard, "", 0), time.Millisecond)
+	stop()
+	stop()
+}
@@ -0,0 +1,45 @@
+package slog
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// LevelOnSignal sets v to the level when the process receives the on signal,
+// and restores the previous level when it receives the off signal.
+// It is typically used to enable debug logging in a running daemon.
+// The returned function stops handling the signals.
+func LevelOnSignal(v *LevelVar, level Level, on, off os.Signal) (stop func()) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, on, off)
+
+	done := make(chan struct{})
+	go func() {
+		var saved Level
+		var raised bool
+		for {
+			select {
+			case sig := <-c:
+				switch {
+				case sig == on && !raised:
+					saved, raised = v.Level(), true
+					v.Set(level)
+				case sig == off && raised:
+					v.Set(saved)
+					raised = false
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(c)
+			close(done)
+		})
+	}
+}
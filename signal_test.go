@@ -0,0 +1,31 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package slog
+
+import (
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestDebugOnSIGUSR(t *testing.T) {
+	var level LevelVar
+	level.Set(LevelWarn)
+	stop := DebugOnSIGUSR(&level)
+	defer stop()
+
+	waitLevel := func(want Level) {
+		t.Helper()
+		for deadline := time.Now().Add(time.Second); level.Level() != want; time.Sleep(time.Millisecond) {
+			if time.Now().After(deadline) {
+				t.Fatalf("level is %v, want %v", level.Level(), want)
+			}
+		}
+	}
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	waitLevel(LevelDebug)
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR2)
+	waitLevel(LevelWarn)
+}
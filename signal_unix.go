@@ -0,0 +1,18 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package slog
+
+import "syscall"
+
+// DebugOnSIGUSR enables debug logging when the process receives SIGUSR1
+// and restores the previous level when it receives SIGUSR2:
+//
+//	var level slog.LevelVar
+//	l := slog.New(os.Stderr, "", slog.LstdFlags, slog.MinLevel(&level))
+//	defer slog.DebugOnSIGUSR(&level)()
+//
+// The returned function stops handling the signals.
+func DebugOnSIGUSR(v *LevelVar) (stop func()) {
+	return LevelOnSignal(v, LevelDebug, syscall.SIGUSR1, syscall.SIGUSR2)
+}
@@ -0,0 +1,158 @@
+package slog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SlackOptions configures a Slack webhook writer.
+type SlackOptions struct {
+	// Level is the minimum level of entries that are posted.
+	// Defaults to LevelWarn.
+	Level Level
+
+	// Fields lists the fields that are included in the message
+	// in addition to the mesg field.
+	Fields []string
+
+	// Every and Burst rate limit the posts to at most Burst messages
+	// followed by one message every interval. Entries that exceed
+	// the rate limit are dropped. Defaults to one message per second
+	// with bursts of five.
+	Every time.Duration
+	Burst int
+
+	// Drops counts the entries that exceed the rate limit, if not nil.
+	Drops *DropCounter
+
+	// Client is the HTTP client used to post the messages.
+	// Defaults to a client with a five second timeout.
+	Client *http.Client
+
+	// Clock tells the time used by the rate limiter. Defaults to SystemClock.
+	Clock Clock
+
+	// ErrorLog logs the messages that could not be posted, if not nil.
+	// It should not write to the Slack writer itself.
+	ErrorLog *log.Logger
+}
+
+var slackEmoji = map[Level]string{
+	LevelTrace: ":mag:",
+	LevelDebug: ":mag:",
+	LevelInfo:  ":information_source:",
+	LevelWarn:  ":warning:",
+	LevelError: ":rotating_light:",
+	LevelFatal: ":skull:",
+}
+
+type slackPost struct {
+	level Level
+	line  []byte
+}
+
+type slackwriter struct {
+	url       string
+	opts      SlackOptions
+	bucket    *bucket
+	posts     chan slackPost
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// Write queues the entry to be posted by a background goroutine,
+// so that a slow or failing webhook never blocks the other outputs.
+// Entries that do not fit in the queue are dropped.
+func (s *slackwriter) Write(p []byte) (int, error) {
+	if level, ok := lineLevel(p); !ok || level < s.opts.Level {
+		return len(p), nil
+	} else if !s.bucket.allow(clockOrSystem(s.opts.Clock).Now()) {
+		s.opts.Drops.Add(DropRateLimit, level)
+	} else {
+		select {
+		case s.posts <- slackPost{level, append([]byte(nil), p...)}:
+		default:
+			s.opts.Drops.Add(DropOverflow, level)
+		}
+	}
+	return len(p), nil
+}
+
+func (s *slackwriter) loop() {
+	defer s.wg.Done()
+	for post := range s.posts {
+		if err := s.post(post.level, post.line); err != nil && s.opts.ErrorLog != nil {
+			s.opts.ErrorLog.Print(err)
+		}
+	}
+}
+
+// Close posts the queued entries and stops the background goroutine.
+// The writer must not be written to after Close.
+func (s *slackwriter) Close() error {
+	s.closeOnce.Do(func() { close(s.posts) })
+	s.wg.Wait()
+	return nil
+}
+
+func (s *slackwriter) post(level Level, p []byte) error {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(p, &fields); err != nil {
+		return err
+	}
+
+	var text bytes.Buffer
+	fmt.Fprintf(&text, "%s %v", slackEmoji[level], fields["mesg"])
+	for _, key := range s.opts.Fields {
+		if val, ok := fields[key]; ok {
+			fmt.Fprintf(&text, "\n*%s*: %v", key, val)
+		}
+	}
+
+	body, _ := json.Marshal(map[string]string{"text": text.String()})
+	res, err := s.opts.Client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("slog: slack webhook: %s", res.Status)
+	}
+	return nil
+}
+
+// NewSlackWriter creates a writer that posts structured log entries
+// to a Slack incoming webhook in the background. It is intended to be combined
+// with other writers using io.MultiWriter and never returns an error.
+// Entries below the minimum level and entries that exceed the rate limit
+// are silently dropped. Close posts the queued entries.
+func NewSlackWriter(url string, opts SlackOptions) io.WriteCloser {
+	if opts.Level == 0 {
+		opts.Level = LevelWarn
+	}
+	if opts.Every <= 0 {
+		opts.Every = time.Second
+	}
+	if opts.Burst <= 0 {
+		opts.Burst = 5
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 5 * time.Second}
+	}
+	s := &slackwriter{
+		url:    url,
+		opts:   opts,
+		bucket: newBucket(opts.Every, opts.Burst),
+		posts:  make(chan slackPost, opts.Burst),
+	}
+	s.wg.Add(1)
+	go s.loop()
+	return s
+}
@@ -0,0 +1,82 @@
+package slog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSlackWriter(t *testing.T) {
+	var mu sync.Mutex
+	var texts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ Text string }
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		texts = append(texts, body.Text)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	w := NewSlackWriter(srv.URL, SlackOptions{
+		Fields: []string{"user"},
+		Every:  time.Hour,
+		Burst:  1,
+	})
+
+	l := New(w, "", Lparsefields|Lmessage)
+	l.Println("level=info user=bob logged in")
+	l.Println("level=error user=bob disk full")
+	l.Println("level=error user=bob disk still full")
+	_ = w.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(texts) != 1 {
+		t.Fatal(texts)
+	} else if !strings.HasPrefix(texts[0], ":rotating_light: level=error user=bob disk full") {
+		t.Fatal(texts[0])
+	} else if !strings.HasSuffix(texts[0], "*user*: bob") {
+		t.Fatal(texts[0])
+	}
+}
+
+func TestSlackWriterFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	var errs bytes.Buffer
+	w := NewSlackWriter(srv.URL, SlackOptions{ErrorLog: log.New(&errs, "", 0)})
+
+	var b bytes.Buffer
+	l := New(io.MultiWriter(w, &b), "", Lparsefields)
+	l.Println("level=error a=1")
+	l.Println("level=error a=2")
+	_ = w.Close()
+
+	if exp := "{\"level\":\"error\",\"a\":1}\n{\"level\":\"error\",\"a\":2}\n"; b.String() != exp {
+		t.Fatal(b.String())
+	} else if !strings.Contains(errs.String(), "500") {
+		t.Fatal(errs.String())
+	}
+}
+
+func TestLineLevel(t *testing.T) {
+	if l, ok := lineLevel([]byte(`{"mesg":"x","level":"WARNING"}`)); !ok || l != LevelWarn {
+		t.Fatal(l, ok)
+	}
+	if _, ok := lineLevel([]byte(`{"mesg":"x"}`)); ok {
+		t.Fatal()
+	}
+}
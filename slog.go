@@ -1,16 +1,23 @@
 // Package slog implements structured logging for lazy gophers.
 //
 // Like the standard logger, slog is configured via flags.
-// It uses all the standard flags and introduces two new ones, Lcolor and Lparsefields.
+// It uses all the standard flags and introduces new ones, such as Lcolor and Lparsefields.
+// Features that need configuration are enabled with Options.
 //
-// Flag Lcolor colorizes the output if the output writer is detected to be a tty.
+// Flag Lcolor colorizes the output if the output writer is detected to be a tty
+// and the NO_COLOR environment variable is not set. Writers that wrap a tty are
+// detected if they have an Unwrap method that returns the wrapped writer.
+// Options ForceColor and NoColor override the detection.
 //
-// Flag Lparsefields parses the log message (including prefix if log.Lmsgprefix is set)
-// for key-value pairs and stores them as separate fields in the JSON object.
+// Flag Lparsefields parses the log message for key-value pairs and stores them
+// as separate fields in the JSON object. The prefix is not parsed, even if
+// log.Lmsgprefix is set, unless option PrefixFields is used.
 // A key-value pair is any fragment of text of the form key=value or key="another value".
 // The key cannot contain spaces and the equals sign cannot be surrounded by spaces.
 // The value can only contain spaces if it is quoted.
 // Slog does not check for duplicate field names.
+// A W3C traceparent field is additionally split into the trace_id, span_id
+// and trace_flags fields.
 //
 // The standard logger produces non-standard timestamps.
 // Slog converts the timestamps to RFC3339 format if the flags
@@ -21,8 +28,29 @@
 // stored in the prfx field. If it is, then it is considered part of the log message.
 // The log message is stored in the mesg field.
 //
+// The components of prefixes chained by Named are joined with dots,
+// so that the prefix "api: db: " is stored as "api.db".
+//
 // If flags log.Llongfile or log.Lshortfile are set, slog parses the file name and line number
 // in two separate fields named fnam and flno.
+//
+// Flag Lfuncname stores the name of the function that called the logger
+// in the func field. The package path is trimmed from the name.
+//
+// Flag Ltimestamp stores the time at which slog writes the entry in the time field,
+// independently of the time stamp of the standard logger, so that the date and
+// time flags can be turned off to save formatting and parsing. The time is formatted
+// with time.RFC3339Nano unless option TimeLayout selects another layout.
+//
+// Flag Lraw stores the original log line in the raw field, which is useful
+// to validate that the parser does not lose information.
+//
+// Flag Lpriority prefixes each line with <N>, where N is the syslog priority of the
+// level found in the level or levl field of the message, as understood by systemd.
+// Lines without a recognized level are given the info priority.
+//
+// Slog avoids copying log lines by converting byte slices to strings with package unsafe.
+// Build with the safe tag to copy them instead in environments that forbid unsafe.
 package slog
 
 import (
@@ -31,9 +59,10 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 	"unicode"
 	"unicode/utf8"
-	"unsafe"
 )
 
 const (
@@ -43,6 +72,14 @@ const (
 	Lparsefields
 	// Lmessage enables the mesg field.
 	Lmessage
+	// Lpriority prefixes each line with the sd-daemon priority of the level.
+	Lpriority
+	// Lfuncname enables the func field.
+	Lfuncname
+	// Lraw enables the raw field.
+	Lraw
+	// Ltimestamp enables the time field stamped by slog.
+	Ltimestamp
 	// LstdFlags defines an initial set of flags.
 	LstdFlags = log.LstdFlags | log.Lmicroseconds | log.LUTC | log.Lmsgprefix | Lcolor | Lparsefields | Lmessage
 )
@@ -103,6 +140,10 @@ func scanKeyVals(s string) (z, key, val string, quote, ok bool) {
 	return
 }
 
+func isSpaceOrPunct(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r)
+}
+
 type colorFunc func([]byte, string) []byte
 
 func color(dst []byte, c string) []byte { return append(dst, c...) }
@@ -112,22 +153,17 @@ const keycol = "\033[34m"
 const strcol = "\033[32m"
 const clrcol = "\033[0m"
 
-func appendKey(dst []byte, s string, col colorFunc) []byte {
+func appendKey(dst []byte, s string, col colorFunc, quote quoteFunc) []byte {
 	dst = col(dst, keycol)
-	dst = strconv.AppendQuote(dst, s)
+	dst = quote(dst, s)
 	dst = col(dst, clrcol)
 	dst = append(dst, ':')
 	return dst
 }
 
-func appendVal(dst []byte, s string) []byte {
-	dst = append(dst, s...)
-	return dst
-}
-
-func appendQuote(dst []byte, s string, col colorFunc) []byte {
+func appendQuote(dst []byte, s string, col colorFunc, quote quoteFunc) []byte {
 	dst = col(dst, strcol)
-	dst = strconv.AppendQuote(dst, s)
+	dst = quote(dst, s)
 	dst = col(dst, clrcol)
 	return dst
 }
@@ -142,39 +178,6 @@ func appendInt(dst []byte, i int64) []byte {
 	return dst
 }
 
-func appendKeyVal(dst []byte, col colorFunc, key, val string, quote bool) []byte {
-	dst = appendKey(dst, key, col)
-
-	// string
-	if quote {
-		return appendQuote(dst, val, col)
-	}
-
-	// keyword
-	switch val {
-	case "true", "false", "null":
-		return appendVal(dst, val)
-	case "<nil>":
-		return appendVal(dst, "null")
-	}
-
-	// number
-	if strings.ContainsAny(val, "0123456789") {
-		if strings.IndexByte(val, '.') >= 0 {
-			if flt, err := strconv.ParseFloat(val, 64); err == nil {
-				return appendFloat(dst, flt)
-			}
-		} else {
-			if i, err := strconv.ParseInt(val, 0, 64); err == nil {
-				return appendInt(dst, i)
-			}
-		}
-	}
-
-	// string
-	return appendQuote(dst, val, col)
-}
-
 func appendComma(dst []byte, comma bool) ([]byte, bool) {
 	if comma {
 		dst = append(dst, ',')
@@ -182,140 +185,354 @@ func appendComma(dst []byte, comma bool) ([]byte, bool) {
 	return dst, true
 }
 
-func parselog(dst []byte, col colorFunc, text, prefix string, flags int) []byte {
-	dst = append(dst, '{')
+func (l *logwriter) appendEntry(dst []byte, e *Entry) []byte {
+	col, quote, flags := l.col, l.quote, l.flags
 
-	text = strings.TrimRightFunc(text, unicode.IsSpace)
+	dst = append(dst, '{')
 
 	var comma bool
 
+	// static fields
+	if l.header != "" {
+		dst = append(dst, l.header...)
+		comma = true
+	}
+
 	// prefix
-	if prefix != "" && flags&log.Lmsgprefix == 0 {
-		text = text[len(prefix):]
-		prefix = strings.TrimSpace(prefix)
-		if prefix != "" {
-			dst = appendKey(dst, "prfx", col)
-			dst = appendQuote(dst, prefix, col)
-			comma = true
-		}
+	if e.Prefix != "" {
+		dst, comma = appendComma(dst, comma)
+		dst = appendKey(dst, l.names.prefix, col, quote)
+		dst = appendQuote(dst, e.Prefix, col, quote)
 	}
 
 	// date and time
-	if flags&(log.Ldate|log.Ltime) != 0 {
+	if flags&(log.Ldate|log.Ltime|log.Lmicroseconds|Ltimestamp) != 0 && !e.Time.IsZero() {
 		dst, comma = appendComma(dst, comma)
-		dst = appendKey(dst, "time", col)
+		dst = appendKey(dst, l.names.time, col, quote)
 		dst = col(dst, strcol)
 		dst = append(dst, '"')
-		if flags&log.Ldate != 0 {
-			ofs := len(dst)
-			dst, text = append(dst, text[:11]...), text[11:]
-			dst[ofs+4] = '-'
-			dst[ofs+7] = '-'
-			dst[ofs+10] = 'T'
-		}
-		if flags&log.Ltime != 0 {
-			n := 8
-			if flags&log.Lmicroseconds != 0 {
-				n += 7
-			}
-			dst, text = append(dst, text[:n]...), text[n+1:]
-		}
-		if flags&(log.Ldate|log.Ltime|log.LUTC) == log.Ldate|log.Ltime|log.LUTC {
-			dst = append(dst, 'Z')
+		if flags&Ltimestamp != 0 {
+			dst = e.Time.AppendFormat(dst, l.timeLayout)
+		} else {
+			dst = appendTime(dst, e.Time, flags)
 		}
 		dst = append(dst, '"')
 		dst = col(dst, clrcol)
 	}
 
 	// file name and line number
-	if flags&(log.Llongfile|log.Lshortfile) != 0 {
-		var file, line string
-		i := strings.IndexByte(text, ':')
-		file, text = text[:i], text[i+1:]
+	if flags&(log.Llongfile|log.Lshortfile) != 0 && e.File != "" {
 		dst, comma = appendComma(dst, comma)
-		dst = appendKey(dst, "fnam", col)
-		dst = appendQuote(dst, file, col)
+		dst = appendKey(dst, l.names.file, col, quote)
+		dst = appendQuote(dst, e.File, col, quote)
 		dst = append(dst, ',')
-		dst = appendKey(dst, "flno", col)
-		i = strings.IndexByte(text, ':')
-		line, text = text[:i], text[i+2:]
-		dst = appendVal(dst, line)
+		dst = appendKey(dst, l.names.line, col, quote)
+		dst = appendInt(dst, int64(e.Line))
+	}
+
+	// function name
+	if e.Func != "" {
+		dst, comma = appendComma(dst, comma)
+		dst = appendKey(dst, l.names.function, col, quote)
+		dst = appendQuote(dst, e.Func, col, quote)
 	}
 
 	// message
-	if flags&Lmessage != 0 {
+	if l.splitText && flags&Lparsefields != 0 {
+		if text := freeText(e.Message); text != "" {
+			dst, comma = appendComma(dst, comma)
+			dst = appendKey(dst, l.names.text, col, quote)
+			dst = appendQuote(dst, text, col, quote)
+		}
+	} else if flags&Lmessage != 0 && !(l.omitStructured && len(e.Fields) > 0 && freeText(e.Message) == "") {
 		dst, comma = appendComma(dst, comma)
-		dst = appendKey(dst, "mesg", col)
-		dst = appendQuote(dst, text, col)
+		dst = appendKey(dst, l.names.message, col, quote)
+		dst = appendQuote(dst, e.Message, col, quote)
+	}
+
+	// raw line
+	if flags&Lraw != 0 {
+		dst, comma = appendComma(dst, comma)
+		dst = appendKey(dst, l.names.raw, col, quote)
+		dst = appendQuote(dst, e.Raw, col, quote)
 	}
 
 	// fields
-	if flags&Lparsefields != 0 && strings.IndexByte(text, '=') != -1 {
-		for len(text) > 0 {
-			var key, val string
-			var quote, ok bool
-			text, key, val, quote, ok = scanKeyVals(text)
-			if ok {
-				dst, comma = appendComma(dst, comma)
-				dst = appendKeyVal(dst, col, key, val, quote)
-			}
+	for _, f := range e.Fields {
+		dst, comma = appendComma(dst, comma)
+		dst = appendKey(dst, f.Key, col, quote)
+		if f.Value.kind == KindFloat && l.floatFmt != 0 {
+			dst = strconv.AppendFloat(dst, f.Value.Float(), l.floatFmt, l.floatPrec, 64)
+		} else if i := int64(f.Value.num); f.Value.kind == KindInt && l.safeInts && (i > maxSafeInt || i < -maxSafeInt) {
+			dst = col(dst, strcol)
+			dst = append(dst, '"')
+			dst = appendInt(dst, i)
+			dst = append(dst, '"')
+			dst = col(dst, clrcol)
+		} else {
+			dst = appendValue(dst, f.Value, col, quote)
 		}
 	}
 
-	return append(dst, "}\n"...)
+	return dst
 }
 
 type logwriter struct {
-	prefix string
-	flags  int
-	buf    []byte
-	col    colorFunc
-	w      io.Writer
+	prefix  string
+	flags   int
+	buf     []byte
+	initBuf int
+	maxBuf  int
+	col     colorFunc
+	names   fieldNames
+	header  string
+	entry   Entry
+	before  []Hook
+	after   []func(*Entry, error)
+	seals   []sealFunc
+	static  []Field
+	drops   *DropCounter
+	stats   *Stats
+	clock   Clock
+
+	colorMode      int
+	omitStructured bool
+	splitText      bool
+	timeLayout     string
+	input          func(l *logwriter, e *Entry, s string) error
+	floatFmt       byte
+	floatPrec      int
+	safeInts       bool
+	quote          quoteFunc
+	asciiOnly      bool
+	htmlSafe       bool
+	directives     bool
+	sinks          map[string]io.Writer
+	directive      directive
+	console        io.Writer
+	consoleBuf     []byte
+	consoleCol     colorFunc
+	mu             *sync.Mutex
+	trailers       []func([]byte) []byte
+	w              io.Writer
 }
 
 func (l *logwriter) Write(p []byte) (int, error) {
-	l.buf = parselog(l.buf[:0], l.col, zcstring(p), l.prefix, l.flags)
-	if _, err := l.w.Write(l.buf); err != nil {
+	return l.WriteString(zcstring(p))
+}
+
+// WriteString implements io.StringWriter.
+// It parses and writes a log line without converting it to a byte slice.
+func (l *logwriter) WriteString(s string) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e := &l.entry
+	text := s
+	if err := l.parse(e, s); err != nil {
+		trimmed := strings.TrimRightFunc(s, unicode.IsSpace)
+		*e = Entry{Message: trimmed, Raw: trimmed, Fields: e.Fields[:0]}
+	} else if l.directives {
+		if stripped, d, ok := l.stripDirective(e, s); ok {
+			_ = l.parse(e, stripped)
+			text = stripped
+			l.directive = d
+			defer func() { l.directive = directive{} }()
+		}
+	}
+	if l.flags&Lfuncname != 0 {
+		e.Func = callerFunc()
+	}
+	if l.flags&Ltimestamp != 0 || l.clock != nil && l.flags&(log.Ldate|log.Ltime|log.Lmicroseconds) != 0 {
+		e.Time = l.now()
+	}
+	e.Fields = append(e.Fields, l.static...)
+	if l.directive.level != 0 {
+		setLevel(e, l.directive.level)
+	}
+
+	if err := l.emit(e, text); err != nil {
 		return 0, err
 	}
-	return len(p), nil
+	return len(s), nil
 }
 
-func zcstring(p []byte) string { return *(*string)(unsafe.Pointer(&p)) }
+// emit runs the hooks and writes the entry to the output writer.
+// The text is the unparsed log line, which is scanned for the level
+// of entries that have no level field. The caller must hold l.mu.
+func (l *logwriter) emit(e *Entry, text string) error {
+	var ok bool
+	if l.buf, ok = l.appendLine(l.buf[:0], e, text); !ok {
+		return nil
+	}
 
-func isterm(w io.Writer) (term bool) {
-	if f, ok := w.(interface{ Stat() (os.FileInfo, error) }); ok {
-		stat, _ := f.Stat()
-		term = stat != nil && stat.Mode()&os.ModeCharDevice != 0
+	w := l.w
+	if l.directive.sink != nil {
+		w = l.directive.sink
+	} else if r, ok := w.(*levelRouter); ok {
+		w = r.route(entryLevel(e, text))
 	}
-	return
+	var err error
+	if l.stats != nil {
+		level, _ := entryLevel(e, text)
+		start := l.stats.clock.Now()
+		_, err = w.Write(l.buf)
+		l.stats.addLatency("out", l.stats.clock.Now().Sub(start))
+		l.stats.addEntry(level, len(l.buf))
+	} else {
+		_, err = w.Write(l.buf)
+	}
+	if l.console != nil {
+		l.consoleBuf = l.appendConsole(l.consoleBuf[:0], e, text)
+		if _, cerr := l.console.Write(l.consoleBuf); err == nil {
+			err = cerr
+		}
+	}
+	if l.maxBuf > 0 && cap(l.buf) > l.maxBuf {
+		l.buf = make([]byte, 0, l.initBuf)
+	}
+	for _, hook := range l.after {
+		hook(e, err)
+	}
+	return err
 }
 
-// NewWriter creates a new structured logging output writer.
-// The prefix and flags of the logger must not be changed afterwards.
-func NewWriter(w io.Writer, l *log.Logger) io.Writer {
-	if w == io.Discard {
-		return io.Discard
+// appendLine runs the before hooks and appends the encoded entry to dst.
+// It returns false if a hook dropped the entry.
+func (l *logwriter) appendLine(dst []byte, e *Entry, text string) ([]byte, bool) {
+	for _, hook := range l.before {
+		if !hook(e) && !l.directive.keep {
+			if l.drops != nil {
+				level, _ := entryLevel(e, text)
+				l.drops.Add(DropFiltered, level)
+			}
+			return dst, false
+		}
+	}
+
+	if l.flags&Lpriority != 0 {
+		dst = appendPriority(dst, e, text)
+	}
+	start := len(dst)
+	dst = l.appendEntry(dst, e)
+	for _, seal := range l.seals {
+		dst = seal(dst, start)
+	}
+	dst = append(dst, "}\n"...)
+	for _, trailer := range l.trailers {
+		dst = trailer(dst)
+	}
+	return dst, true
+}
+
+// isterm reports whether w writes to a terminal. Writers that wrap other writers
+// are probed through an Unwrap method that returns the wrapped writer or writers,
+// all of which must be terminals.
+func isterm(w io.Writer) bool {
+	for depth := 0; depth < 16; depth++ {
+		switch u := w.(type) {
+		case interface{ Stat() (os.FileInfo, error) }:
+			stat, _ := u.Stat()
+			return stat != nil && stat.Mode()&os.ModeCharDevice != 0
+		case interface{ Unwrap() io.Writer }:
+			w = u.Unwrap()
+		case interface{ Unwrap() []io.Writer }:
+			ws := u.Unwrap()
+			for _, w := range ws {
+				if !isterm(w) {
+					return false
+				}
+			}
+			return len(ws) > 0
+		default:
+			return false
+		}
 	}
+	return false
+}
 
+// setColor enables colors if they are forced, or if Lcolor is set,
+// the output is a terminal and the NO_COLOR environment variable is not set.
+func (l *logwriter) setColor(w io.Writer) {
+	switch l.colorMode {
+	case colorForce:
+		l.col = color
+	case colorAuto:
+		if l.flags&Lcolor != 0 && os.Getenv("NO_COLOR") == "" && isterm(w) {
+			l.col = color
+		}
+	}
+}
+
+func newLogwriter(w io.Writer, prefix string, flags int, opts []Option) *logwriter {
 	var lw logwriter
-	lw.prefix = l.Prefix()
-	lw.flags = l.Flags()
-	lw.buf = make([]byte, 0, 256)
+	lw.prefix = prefix
+	lw.flags = flags
+	lw.initBuf = 256
 	lw.col = plain
+	lw.names = defaultNames
+	lw.timeLayout = time.RFC3339Nano
+	lw.mu = &sync.Mutex{}
 	lw.w = w
 
-	if l.Flags()&Lcolor != 0 && isterm(w) {
-		lw.col = color
+	for _, opt := range opts {
+		opt(&lw)
 	}
 
+	lw.quote = lw.quoter()
+	lw.buf = make([]byte, 0, lw.initBuf)
+	if lw.console != nil {
+		lw.setConsoleColor()
+	}
 	return &lw
 }
 
+// parse parses a log line in the input format of the writer.
+func (l *logwriter) parse(e *Entry, s string) error {
+	if l.input != nil {
+		return l.input(l, e, s)
+	}
+	return parseEntry(e, s, l.prefix, l.flags)
+}
+
+// now returns the time told by the clock in the time zone selected by the flags.
+func (l *logwriter) now() time.Time {
+	t := clockOrSystem(l.clock).Now()
+	if l.flags&log.LUTC != 0 {
+		t = t.UTC()
+	}
+	return t
+}
+
+// with returns a copy of the writer that adds the fields to every entry.
+// The copy shares the options and the output writer with the original.
+func (l *logwriter) with(fields ...Field) *logwriter {
+	lw := *l
+	lw.buf = make([]byte, 0, l.initBuf)
+	lw.consoleBuf = nil
+	lw.entry = Entry{}
+	lw.static = append(l.static[:len(l.static):len(l.static)], fields...)
+	return &lw
+}
+
+// NewWriter creates a new structured logging output writer.
+// The prefix and flags of the logger must not be changed afterwards.
+func NewWriter(w io.Writer, l *log.Logger, opts ...Option) io.Writer {
+	if w == io.Discard {
+		return io.Discard
+	}
+
+	lw := newLogwriter(w, l.Prefix(), l.Flags(), opts)
+
+	lw.setColor(w)
+
+	return lw
+}
+
 // New creates a new log.Logger that produces structured logs.
 // The prefix and flags of the logger must not be changed afterwards.
-func New(w io.Writer, prefix string, flag int) *log.Logger {
+func New(w io.Writer, prefix string, flag int, opts ...Option) *log.Logger {
 	l := log.New(nil, prefix, flag)
-	l.SetOutput(NewWriter(w, l))
+	l.SetOutput(NewWriter(w, l, opts...))
 	return l
 }
@@ -3,11 +3,26 @@ package slog
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"log"
+	"os"
 	"testing"
 	"time"
 )
 
+// setenv sets the environment variable and returns a function that restores it.
+func setenv(key, value string) (restore func()) {
+	old, ok := os.LookupEnv(key)
+	os.Setenv(key, value)
+	return func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
 func TestScanKeyVals(t *testing.T) {
 	if _, k, v, q, ok := scanKeyVals("hello=world"); k != "hello" || v != "world" || q || !ok {
 		t.Fatal()
@@ -117,6 +132,16 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestPrefixPunctuation(t *testing.T) {
+	for _, prefix := range []string{"app ", "app: ", "[app] ", "app - ", "(app) "} {
+		var b bytes.Buffer
+		New(&b, prefix, 0).Print("hello")
+		if exp := "{\"prfx\":\"app\"}\n"; b.String() != exp {
+			t.Fatal(prefix, b.String())
+		}
+	}
+}
+
 func TestNoLmessage(t *testing.T) {
 	var b bytes.Buffer
 	var m map[string]string
@@ -162,3 +187,18 @@ func BenchmarkSlogParseFields(b *testing.B) {
 	}
 	b.StopTimer()
 }
+
+func TestWriteString(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, log.New(nil, "", Lparsefields|Lmessage))
+	sw, ok := w.(io.StringWriter)
+	if !ok {
+		t.Fatal("writer does not implement io.StringWriter")
+	}
+	if n, err := sw.WriteString("hello a=1\n"); err != nil || n != 10 {
+		t.Fatal(n, err)
+	}
+	if want := `{"mesg":"hello a=1","a":1}` + "\n"; b.String() != want {
+		t.Fatal(b.String())
+	}
+}
@@ -0,0 +1,61 @@
+// Package slogecho implements request logging and recovery middleware for Echo
+// with the same fields as the net/http middleware of slog.
+//
+//	e := echo.New()
+//	e.Use(slogecho.Middleware(l, slog.MiddlewareOptions{}), slogecho.Recover(l))
+//
+// It is a separate module, so that slog does not depend on Echo.
+package slogecho
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/askeladdk/slog"
+	"github.com/labstack/echo/v4"
+)
+
+// Middleware returns Echo middleware that assigns a request id to every request
+// and logs one entry per request like slog.Middleware. Unlike adapting
+// slog.Middleware with echo.WrapMiddleware, the errors returned by the handlers
+// are handled before the entry is logged, so that it has the status of the error.
+// Handlers can use slog.FromContext with the context of c.Request() to log entries
+// with the same reqid field.
+func Middleware(l *log.Logger, opts slog.MiddlewareOptions) echo.MiddlewareFunc {
+	mw := slog.Middleware(l, opts)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			res := c.Response()
+			mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c.SetRequest(r)
+				c.SetResponse(echo.NewResponse(w, c.Echo()))
+				if err := next(c); err != nil {
+					c.Error(err)
+				}
+			})).ServeHTTP(res, c.Request())
+			return nil
+		}
+	}
+}
+
+// Recover returns Echo middleware that recovers from panics in the handlers,
+// logs them like slog.Recover and responds with status 500, unless the handler
+// already wrote the response. The http.ErrAbortHandler panic is not recovered.
+func Recover(l *log.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			defer func() {
+				if v := recover(); v != nil {
+					if v == http.ErrAbortHandler {
+						panic(v)
+					}
+					slog.LogPanic(c.Request().Context(), l, v)
+					if !c.Response().Committed {
+						err = echo.ErrInternalServerError
+					}
+				}
+			}()
+			return next(c)
+		}
+	}
+}
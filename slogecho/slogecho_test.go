@@ -0,0 +1,54 @@
+package slogecho
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/askeladdk/slog"
+	"github.com/labstack/echo/v4"
+)
+
+func TestMiddleware(t *testing.T) {
+	var b bytes.Buffer
+	l := slog.New(&b, "", slog.Lparsefields)
+	e := echo.New()
+	e.Use(Middleware(l, slog.MiddlewareOptions{}), Recover(l))
+	e.GET("/ok", func(c echo.Context) error {
+		if slog.RequestID(c.Request().Context()) == "" {
+			t.Error("no request id")
+		}
+		return c.String(http.StatusCreated, "hello")
+	})
+	e.GET("/missing", func(c echo.Context) error {
+		return echo.ErrNotFound
+	})
+	e.GET("/panic", func(c echo.Context) error {
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/ok", nil))
+	if rec.Code != http.StatusCreated || rec.Header().Get(slog.DefaultRequestIDHeader) == "" {
+		t.Fatal(rec.Code, rec.Header())
+	} else if !strings.Contains(b.String(), `"status":201,"size":5`) {
+		t.Fatal(b.String())
+	}
+
+	b.Reset()
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/missing", nil))
+	if !strings.Contains(b.String(), `"status":404`) {
+		t.Fatal(b.String())
+	}
+
+	b.Reset()
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/panic", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatal(rec.Code)
+	} else if !strings.Contains(b.String(), `"panic":"boom"`) || !strings.Contains(b.String(), `"status":500`) {
+		t.Fatal(b.String())
+	}
+}
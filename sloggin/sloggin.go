@@ -0,0 +1,69 @@
+// Package sloggin implements request logging and recovery middleware for Gin
+// with the same fields as the net/http middleware of slog.
+//
+//	r := gin.New()
+//	r.Use(sloggin.Middleware(l, slog.MiddlewareOptions{}), sloggin.Recover(l))
+//
+// It is a separate module, so that slog does not depend on Gin.
+package sloggin
+
+import (
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/askeladdk/slog"
+	"github.com/gin-gonic/gin"
+)
+
+// responseWriter sends the response of the handlers through the writer
+// of the slog middleware, so that it records the status and size.
+type responseWriter struct {
+	gin.ResponseWriter
+	w http.ResponseWriter
+}
+
+func (rw *responseWriter) WriteHeader(status int) { rw.w.WriteHeader(status) }
+
+func (rw *responseWriter) Write(p []byte) (int, error) { return rw.w.Write(p) }
+
+func (rw *responseWriter) WriteString(s string) (int, error) { return io.WriteString(rw.w, s) }
+
+// Middleware returns Gin middleware that assigns a request id to every request
+// and logs one entry per request like slog.Middleware. Handlers can use
+// slog.FromContext with the context of c.Request to log entries with the same reqid field.
+func Middleware(l *log.Logger, opts slog.MiddlewareOptions) gin.HandlerFunc {
+	mw := slog.Middleware(l, opts)
+	return func(c *gin.Context) {
+		orig := c.Writer
+		mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c.Request = r
+			c.Writer = &responseWriter{orig, w}
+			c.Next()
+		})).ServeHTTP(orig, c.Request)
+		c.Writer = orig
+	}
+}
+
+// Recover returns Gin middleware that recovers from panics in the handlers,
+// logs them like slog.Recover and aborts the request with status 500,
+// unless the handler already wrote the response.
+// The http.ErrAbortHandler panic is not recovered.
+func Recover(l *log.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if v := recover(); v != nil {
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+				slog.LogPanic(c.Request.Context(), l, v)
+				if c.Writer.Written() {
+					c.Abort()
+				} else {
+					c.AbortWithStatus(http.StatusInternalServerError)
+				}
+			}
+		}()
+		c.Next()
+	}
+}
@@ -0,0 +1,47 @@
+package sloggin
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/askeladdk/slog"
+	"github.com/gin-gonic/gin"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var b bytes.Buffer
+	l := slog.New(&b, "", slog.Lparsefields)
+	r := gin.New()
+	r.Use(Middleware(l, slog.MiddlewareOptions{}), Recover(l))
+	r.GET("/ok", func(c *gin.Context) {
+		if slog.RequestID(c.Request.Context()) == "" {
+			t.Error("no request id")
+		}
+		c.String(http.StatusCreated, "hello")
+	})
+	r.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/ok", nil))
+	if rec.Code != http.StatusCreated || rec.Header().Get(slog.DefaultRequestIDHeader) == "" {
+		t.Fatal(rec.Code, rec.Header())
+	} else if !strings.Contains(b.String(), `"status":201,"size":5`) {
+		t.Fatal(b.String())
+	}
+
+	b.Reset()
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/panic", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatal(rec.Code)
+	} else if !strings.Contains(b.String(), `"panic":"boom"`) || !strings.Contains(b.String(), `"status":500`) {
+		t.Fatal(b.String())
+	}
+}
@@ -0,0 +1,28 @@
+package slogtest
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a deterministic clock that implements slog.Clock.
+// Every reading advances the time by a fixed step.
+type Clock struct {
+	mu   sync.Mutex
+	now  time.Time
+	step time.Duration
+}
+
+// NewClock creates a clock that starts at start and advances by step every time it is read.
+func NewClock(start time.Time, step time.Duration) *Clock {
+	return &Clock{now: start, step: step}
+}
+
+// Now returns the current time of the clock and advances it.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now
+	c.now = c.now.Add(c.step)
+	return now
+}
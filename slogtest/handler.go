@@ -0,0 +1,68 @@
+//go:build go1.21
+// +build go1.21
+
+package slogtest
+
+import (
+	"bytes"
+	"io"
+	stdslog "log/slog"
+	"strings"
+	"testing"
+	"testing/slogtest"
+
+	"github.com/askeladdk/slog"
+)
+
+// Results decodes the entries written to buf into the form expected by
+// testing/slogtest. The time, level and message are stored under the keys
+// of log/slog, and fields with dotted keys are nested in groups.
+func Results(buf *bytes.Buffer) func() []map[string]interface{} {
+	return func() []map[string]interface{} {
+		var ms []map[string]interface{}
+		d := slog.NewDecoder(bytes.NewReader(buf.Bytes()))
+		for {
+			var e slog.Entry
+			if err := d.Decode(&e); err != nil {
+				return ms
+			}
+
+			m := map[string]interface{}{}
+			if !e.Time.IsZero() {
+				m[stdslog.TimeKey] = e.Time
+			}
+			if e.Message != "" {
+				m[stdslog.MessageKey] = e.Message
+			}
+			for _, f := range e.Fields {
+				if f.Key == "levl" || f.Key == "level" {
+					m[stdslog.LevelKey] = f.Value.Any()
+					continue
+				}
+				path := strings.Split(f.Key, ".")
+				g := m
+				for _, name := range path[:len(path)-1] {
+					sub, ok := g[name].(map[string]interface{})
+					if !ok {
+						sub = map[string]interface{}{}
+						g[name] = sub
+					}
+					g = sub
+				}
+				g[path[len(path)-1]] = f.Value.Any()
+			}
+			ms = append(ms, m)
+		}
+	}
+}
+
+// TestHandler checks that a Handler created with the flags and options
+// conforms to testing/slogtest. The flags must enable the time and the message.
+func TestHandler(t *testing.T, flags int, opts ...slog.Option) {
+	t.Helper()
+	var buf bytes.Buffer
+	h := slog.NewHandler(io.Writer(&buf), flags, stdslog.LevelDebug, opts...)
+	if err := slogtest.TestHandler(h, Results(&buf)); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build go1.21
+// +build go1.21
+
+package slogtest
+
+import (
+	"log"
+	"testing"
+
+	"github.com/askeladdk/slog"
+)
+
+func TestHandlerCompliance(t *testing.T) {
+	TestHandler(t, slog.LstdFlags)
+	TestHandler(t, log.LstdFlags|slog.Lmessage, slog.Logstash())
+	TestHandler(t, slog.LstdFlags|log.Lshortfile|slog.Lfuncname, slog.SplitMessage())
+}
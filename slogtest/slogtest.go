@@ -0,0 +1,100 @@
+// Package slogtest implements helpers for testing code that logs with slog.
+//
+// A typical test logs to a buffer and inspects the entries:
+//
+//	var buf bytes.Buffer
+//	l := slog.New(&buf, "", slog.LstdFlags)
+//	doSomething(l)
+//	entries := slogtest.Entries(t, &buf)
+//	slogtest.HasField(t, entries[0], "user", "alice")
+package slogtest
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/askeladdk/slog"
+)
+
+// UpdateEnv is the name of the environment variable that makes Golden
+// overwrite the golden files instead of comparing against them.
+const UpdateEnv = "SLOGTEST_UPDATE"
+
+// Entries decodes all entries read from r. It fails the test if r
+// contains a line that is not a structured log entry.
+func Entries(t testing.TB, r io.Reader) []slog.Entry {
+	t.Helper()
+	var entries []slog.Entry
+	d := slog.NewDecoder(r)
+	for {
+		var e slog.Entry
+		if err := d.Decode(&e); err == io.EOF {
+			return entries
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, e)
+	}
+}
+
+// HasField fails the test if the entry does not have a field with the key
+// whose value equals want. Numbers of any Go type compare equal if their values do.
+func HasField(t testing.TB, e slog.Entry, key string, want interface{}) {
+	t.Helper()
+	v, ok := e.Get(key)
+	if !ok {
+		t.Errorf("slogtest: field %s not found in %q", key, e.Message)
+		return
+	}
+	if got, want := v.Any(), slog.AnyValue(want).Any(); !reflect.DeepEqual(got, want) {
+		t.Errorf("slogtest: field %s: got %v (%T), want %v (%T)", key, got, got, want, want)
+	}
+}
+
+// NoField fails the test if the entry has a field with the key.
+func NoField(t testing.TB, e slog.Entry, key string) {
+	t.Helper()
+	if v, ok := e.Get(key); ok {
+		t.Errorf("slogtest: unexpected field %s=%v", key, v)
+	}
+}
+
+var timeField = regexp.MustCompile(`"(time|@timestamp)":"[^"]*"`)
+
+// NormalizeTime replaces the values of the time and @timestamp fields
+// by a fixed placeholder, so that the output does not depend on the clock.
+func NormalizeTime(p []byte) []byte {
+	return timeField.ReplaceAll(p, []byte(`"$1":"<time>"`))
+}
+
+// Golden compares the output with time stamps normalized to the contents
+// of testdata/name. The golden file is written instead if the environment
+// variable SLOGTEST_UPDATE is set to a non-empty value.
+func Golden(t testing.TB, name string, got []byte) {
+	t.Helper()
+	got = NormalizeTime(got)
+	path := filepath.Join("testdata", name)
+
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("slogtest: %v (set %s=1 to create it)", err, UpdateEnv)
+	}
+	if want = NormalizeTime(want); !bytes.Equal(got, want) {
+		t.Errorf("slogtest: output does not match %s\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package slogtest
+
+import (
+	"bytes"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/askeladdk/slog"
+)
+
+func TestEntries(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(&buf, "", log.LstdFlags|log.LUTC|slog.Lparsefields|slog.Lmessage)
+	l.Print("login user=alice attempts=3 ok=true")
+	l.Print("logout")
+
+	entries := Entries(t, &buf)
+	if len(entries) != 2 {
+		t.Fatal(entries)
+	}
+	HasField(t, entries[0], "user", "alice")
+	HasField(t, entries[0], "attempts", 3)
+	HasField(t, entries[0], "ok", true)
+	NoField(t, entries[1], "user")
+}
+
+func TestGolden(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(&buf, "app: ", log.LstdFlags|log.LUTC|slog.Lparsefields|slog.Lmessage)
+	l.Print("hello a=1")
+	Golden(t, "hello.golden", buf.Bytes())
+}
+
+func TestNormalizeTime(t *testing.T) {
+	got := NormalizeTime([]byte(`{"time":"2021-01-01T00:00:00Z","@timestamp":"x","a":1}`))
+	if want := `{"time":"<time>","@timestamp":"<time>","a":1}`; string(got) != want {
+		t.Fatal(string(got))
+	}
+}
+
+func TestClock(t *testing.T) {
+	start := time.Unix(0, 0)
+	c := NewClock(start, time.Second)
+	if c.Now() != start || c.Now() != start.Add(time.Second) {
+		t.Fatal("clock does not advance")
+	}
+}
@@ -0,0 +1,114 @@
+package slog
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// SQLOptions configures an SQLLogger.
+type SQLOptions struct {
+	// SlowThreshold is the duration above which queries are logged
+	// at the warn level with the slow field. Defaults to 200 milliseconds.
+	SlowThreshold time.Duration
+
+	// Level is the minimum level of the entries that are logged.
+	// Queries are logged at the info level unless they are slow or fail.
+	// Defaults to LevelInfo.
+	Level Level
+
+	// IgnoreError reports whether an error returned by a query is expected,
+	// such as a record not found error, in which case the query is not
+	// logged as an error.
+	IgnoreError func(error) bool
+
+	// Clock tells the time used to measure the duration of queries.
+	// Defaults to SystemClock.
+	Clock Clock
+}
+
+// SQLLogger logs the queries of an ORM as entries with the sql, rows, durms, error and slow fields.
+// Its methods have the signatures of the logger interface of GORM,
+// which can be implemented by adding the LogMode method:
+//
+//	type gormLogger struct{ *slog.SQLLogger }
+//
+//	func (g gormLogger) LogMode(logger.LogLevel) logger.Interface { return g }
+//
+//	db, err := gorm.Open(dialector, &gorm.Config{
+//		Logger: gormLogger{slog.NewSQLLogger(l, slog.SQLOptions{
+//			IgnoreError: func(err error) bool { return errors.Is(err, gorm.ErrRecordNotFound) },
+//		})},
+//	})
+type SQLLogger struct {
+	l    *log.Logger
+	opts SQLOptions
+}
+
+// NewSQLLogger creates an SQL logger that writes to l.
+func NewSQLLogger(l *log.Logger, opts SQLOptions) *SQLLogger {
+	if opts.SlowThreshold <= 0 {
+		opts.SlowThreshold = 200 * time.Millisecond
+	}
+	if opts.Level == 0 {
+		opts.Level = LevelInfo
+	}
+	return &SQLLogger{l, opts}
+}
+
+func (s *SQLLogger) printf(ctx context.Context, level Level, format string, args []interface{}) {
+	if level >= s.opts.Level {
+		_ = FromContext(ctx, s.l).Output(3, fmt.Sprintf(format, args...)+" levl="+level.String())
+	}
+}
+
+// Info logs a formatted message at the info level.
+func (s *SQLLogger) Info(ctx context.Context, format string, args ...interface{}) {
+	s.printf(ctx, LevelInfo, format, args)
+}
+
+// Warn logs a formatted message at the warn level.
+func (s *SQLLogger) Warn(ctx context.Context, format string, args ...interface{}) {
+	s.printf(ctx, LevelWarn, format, args)
+}
+
+// Error logs a formatted message at the error level.
+func (s *SQLLogger) Error(ctx context.Context, format string, args ...interface{}) {
+	s.printf(ctx, LevelError, format, args)
+}
+
+// Trace logs a query that started at begin. The function fc returns the query
+// and the number of affected rows, which is omitted if negative.
+func (s *SQLLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rows int64), err error) {
+	elapsed := clockOrSystem(s.opts.Clock).Now().Sub(begin)
+
+	level, slow := LevelInfo, elapsed > s.opts.SlowThreshold
+	if err != nil && (s.opts.IgnoreError == nil || !s.opts.IgnoreError(err)) {
+		level = LevelError
+	} else if slow {
+		level = LevelWarn
+	}
+	if level < s.opts.Level {
+		return
+	}
+
+	sql, rows := fc()
+	msg := "levl=" + level.String() + " " + KV("sql", sql)
+	if rows >= 0 {
+		msg += " " + KV("rows", rows)
+	}
+	msg += fmt.Sprintf(" durms=%.3f", float64(elapsed)/float64(time.Millisecond))
+	if err != nil {
+		msg += " " + KV("error", err)
+	}
+	if slow {
+		msg += " slow=true"
+	}
+	_ = FromContext(ctx, s.l).Output(2, msg)
+}
+
+func init() {
+	registerWrappers((*SQLLogger).printf, (*SQLLogger).Info, (*SQLLogger).Warn,
+		(*SQLLogger).Error, (*SQLLogger).Trace)
+}
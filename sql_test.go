@@ -0,0 +1,40 @@
+package slog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSQLLogger(t *testing.T) {
+	errNotFound := errors.New("record not found")
+	now := time.Unix(0, 0)
+
+	var b bytes.Buffer
+	s := NewSQLLogger(New(&b, "", Lparsefields), SQLOptions{
+		Level:       LevelWarn,
+		IgnoreError: func(err error) bool { return err == errNotFound },
+		Clock:       fixedClock(now),
+	})
+
+	query := func(sql string, rows int64) func() (string, int64) {
+		return func() (string, int64) { return sql, rows }
+	}
+
+	ctx := context.Background()
+	s.Trace(ctx, now.Add(-time.Millisecond), query("SELECT 1", 1), nil)
+	s.Trace(ctx, now.Add(-time.Millisecond), query("SELECT * FROM users WHERE id = 1", 0), errNotFound)
+	s.Trace(ctx, now.Add(-time.Second), query("SELECT * FROM users", 100), nil)
+	s.Trace(ctx, now.Add(-time.Millisecond), query(`INSERT INTO users VALUES ("x")`, -1), errors.New("duplicate key"))
+	s.Info(ctx, "hidden %d", 1)
+	s.Warn(ctx, "shown %d", 2)
+
+	want := `{"levl":"warn","sql":"SELECT * FROM users","rows":100,"durms":1000,"slow":true}` + "\n" +
+		`{"levl":"error","sql":"INSERT INTO users VALUES ('x')","durms":1,"error":"duplicate key"}` + "\n" +
+		`{"levl":"warn"}` + "\n"
+	if b.String() != want {
+		t.Fatal(b.String())
+	}
+}
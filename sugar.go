@@ -0,0 +1,93 @@
+package slog
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// formatw formats a message followed by the level, if any, and the key-value pairs.
+func formatw(level Level, msg string, kv []interface{}) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	if level != 0 {
+		b.WriteString(" levl=")
+		b.WriteString(level.String())
+	}
+	for i := 0; i < len(kv); i += 2 {
+		var val interface{}
+		if i+1 < len(kv) {
+			val = kv[i+1]
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(KV(fmt.Sprint(kv[i]), val))
+	}
+	return b.String()
+}
+
+func outputw(l *log.Logger, level Level, msg string, kv []interface{}) {
+	if l == nil {
+		l = Default()
+	}
+	_ = l.Output(3, formatw(level, msg, kv))
+}
+
+// Printw logs the message followed by the alternating keys and values formatted
+// by KV, so that they are parsed as fields. A key without a value is given the null value.
+// The default logger is used if l is nil.
+//
+//	slog.Printw(l, "login", "user", name, "ok", true)
+func Printw(l *log.Logger, msg string, kv ...interface{}) { outputw(l, 0, msg, kv) }
+
+// Tracew is like Printw and adds the trace level in the levl field.
+func Tracew(l *log.Logger, msg string, kv ...interface{}) { outputw(l, LevelTrace, msg, kv) }
+
+// Debugw is like Printw and adds the debug level in the levl field.
+func Debugw(l *log.Logger, msg string, kv ...interface{}) { outputw(l, LevelDebug, msg, kv) }
+
+// Infow is like Printw and adds the info level in the levl field.
+func Infow(l *log.Logger, msg string, kv ...interface{}) { outputw(l, LevelInfo, msg, kv) }
+
+// Warnw is like Printw and adds the warn level in the levl field.
+func Warnw(l *log.Logger, msg string, kv ...interface{}) { outputw(l, LevelWarn, msg, kv) }
+
+// Errorw is like Printw and adds the error level in the levl field.
+func Errorw(l *log.Logger, msg string, kv ...interface{}) { outputw(l, LevelError, msg, kv) }
+
+// LeveledLogger adapts a logger to the leveled key-value interface of libraries
+// such as hashicorp/go-retryablehttp, whose methods take a message followed by
+// alternating keys and values. The pairs are formatted like Printw.
+//
+//	client := retryablehttp.NewClient()
+//	client.Logger = slog.NewLeveledLogger(l)
+type LeveledLogger struct {
+	l *log.Logger
+}
+
+// NewLeveledLogger creates a leveled logger that writes to l.
+// The default logger is used if l is nil.
+func NewLeveledLogger(l *log.Logger) *LeveledLogger {
+	return &LeveledLogger{l}
+}
+
+// Debug logs at the debug level.
+func (ll *LeveledLogger) Debug(msg string, kv ...interface{}) { outputw(ll.l, LevelDebug, msg, kv) }
+
+// Info logs at the info level.
+func (ll *LeveledLogger) Info(msg string, kv ...interface{}) { outputw(ll.l, LevelInfo, msg, kv) }
+
+// Warn logs at the warn level.
+func (ll *LeveledLogger) Warn(msg string, kv ...interface{}) { outputw(ll.l, LevelWarn, msg, kv) }
+
+// Error logs at the error level.
+func (ll *LeveledLogger) Error(msg string, kv ...interface{}) { outputw(ll.l, LevelError, msg, kv) }
+
+// V returns a Verbose that logs to the logger if level n is enabled by DefaultVerbosity.
+func (ll *LeveledLogger) V(n int) Verbose { return DefaultVerbosity.V(ll.l, n) }
+
+func init() {
+	registerWrappers(Printw, Tracew, Debugw, Infow, Warnw, Errorw, outputw,
+		(*LeveledLogger).Debug, (*LeveledLogger).Info, (*LeveledLogger).Warn, (*LeveledLogger).Error)
+}
@@ -0,0 +1,42 @@
+package slog
+
+import (
+	"bytes"
+	"log"
+	"testing"
+)
+
+func TestPrintw(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", log.Lshortfile|Lfuncname|Lparsefields|Lmessage)
+
+	Printw(l, "login", "user", "alice smith", "ok", true)
+	Errorw(l, "failed", "err", "not found", "code")
+
+	want := `{"fnam":"sugar_test.go","flno":13,"func":"slog.TestPrintw","mesg":"login user=\"alice smith\" ok=true","user":"alice smith","ok":true}` + "\n" +
+		`{"fnam":"sugar_test.go","flno":14,"func":"slog.TestPrintw","mesg":"failed levl=error err=\"not found\" code=null","levl":"error","err":"not found","code":null}` + "\n"
+	if b.String() != want {
+		t.Fatal(b.String())
+	}
+}
+
+// leveledLogger mirrors the retryablehttp.LeveledLogger interface.
+type leveledLogger interface {
+	Error(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Debug(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+}
+
+var _ leveledLogger = (*LeveledLogger)(nil)
+
+func TestLeveledLogger(t *testing.T) {
+	var b bytes.Buffer
+	ll := NewLeveledLogger(New(&b, "", log.Lshortfile|Lfuncname|Lparsefields|Lmessage))
+	ll.Debug("retrying request", "url", "http://x/y", "retry", 2)
+
+	want := `{"fnam":"sugar_test.go","flno":36,"func":"slog.TestLeveledLogger","mesg":"retrying request levl=debug url=http://x/y retry=2","levl":"debug","url":"http://x/y","retry":2}` + "\n"
+	if b.String() != want {
+		t.Fatal(b.String())
+	}
+}
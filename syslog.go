@@ -0,0 +1,155 @@
+package slog
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Facility is a syslog facility.
+type Facility int
+
+// Syslog facilities.
+const (
+	FacilityKern Facility = iota
+	FacilityUser
+	FacilityMail
+	FacilityDaemon
+	FacilityAuth
+	FacilitySyslog
+	FacilityLpr
+	FacilityNews
+	FacilityUucp
+	FacilityCron
+	FacilityAuthpriv
+	FacilityFtp
+	FacilityLocal0 Facility = iota + 4
+	FacilityLocal1
+	FacilityLocal2
+	FacilityLocal3
+	FacilityLocal4
+	FacilityLocal5
+	FacilityLocal6
+	FacilityLocal7
+)
+
+var facilityNames = map[string]Facility{
+	"kern":     FacilityKern,
+	"user":     FacilityUser,
+	"mail":     FacilityMail,
+	"daemon":   FacilityDaemon,
+	"auth":     FacilityAuth,
+	"syslog":   FacilitySyslog,
+	"lpr":      FacilityLpr,
+	"news":     FacilityNews,
+	"uucp":     FacilityUucp,
+	"cron":     FacilityCron,
+	"authpriv": FacilityAuthpriv,
+	"ftp":      FacilityFtp,
+	"local0":   FacilityLocal0,
+	"local1":   FacilityLocal1,
+	"local2":   FacilityLocal2,
+	"local3":   FacilityLocal3,
+	"local4":   FacilityLocal4,
+	"local5":   FacilityLocal5,
+	"local6":   FacilityLocal6,
+	"local7":   FacilityLocal7,
+}
+
+// ParseFacility parses a facility name such as auth or local0 case-insensitively.
+func ParseFacility(s string) (Facility, bool) {
+	f, ok := facilityNames[strings.ToLower(s)]
+	return f, ok
+}
+
+// SyslogOptions configures a syslog writer.
+type SyslogOptions struct {
+	// Facility is the facility of entries that do not specify one.
+	// Defaults to FacilityUser, because FacilityKern is reserved for the kernel.
+	Facility Facility
+
+	// FacilityField is the name of the field whose value selects
+	// the facility of an entry, such as facility=auth.
+	// Unknown facility names fall back to Facility.
+	// The facility is fixed if empty.
+	FacilityField string
+
+	// Tag is the program name. Defaults to the base name of os.Args[0].
+	Tag string
+
+	// Hostname is the host name. Defaults to os.Hostname.
+	Hostname string
+
+	// Clock tells the time stamps of the messages. Defaults to SystemClock.
+	Clock Clock
+}
+
+type syslogwriter struct {
+	mu   sync.Mutex
+	buf  []byte
+	w    io.Writer
+	opts SyslogOptions
+}
+
+func (w *syslogwriter) Write(p []byte) (int, error) {
+	facility := w.opts.Facility
+	if w.opts.FacilityField != "" {
+		if name, ok := lineString(p, w.opts.FacilityField); ok {
+			if f, ok := ParseFacility(name); ok {
+				facility = f
+			}
+		}
+	}
+
+	level, ok := lineLevel(p)
+	if !ok {
+		level = LevelInfo
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// <PRI>TIMESTAMP HOSTNAME TAG[PID]: MSG
+	b := append(w.buf[:0], '<')
+	b = strconv.AppendInt(b, int64(facility)*8+int64(level.priority()), 10)
+	b = append(b, '>')
+	b = clockOrSystem(w.opts.Clock).Now().AppendFormat(b, time.RFC3339)
+	b = append(b, ' ')
+	b = append(b, w.opts.Hostname...)
+	b = append(b, ' ')
+	b = append(b, w.opts.Tag...)
+	b = append(b, '[')
+	b = strconv.AppendInt(b, int64(os.Getpid()), 10)
+	b = append(b, "]: "...)
+	b = append(b, p...)
+	if len(p) == 0 || p[len(p)-1] != '\n' {
+		b = append(b, '\n')
+	}
+	w.buf = b
+
+	if _, err := w.w.Write(b); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// NewSyslogWriter creates a writer that sends entries to a syslog daemon
+// through w, which is typically a connection to /dev/log or a remote daemon
+// obtained with Dial or net.Dial. The priority of each message is computed from the
+// level found in the level or levl field and the facility of the entry.
+func NewSyslogWriter(w io.Writer, opts SyslogOptions) io.Writer {
+	if opts.Facility == FacilityKern {
+		opts.Facility = FacilityUser
+	}
+	if opts.Tag == "" {
+		opts.Tag = filepath.Base(os.Args[0])
+	}
+	if opts.Hostname == "" {
+		opts.Hostname, _ = os.Hostname()
+	}
+	return &syslogwriter{w: w, opts: opts}
+}
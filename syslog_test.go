@@ -0,0 +1,40 @@
+package slog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSyslogWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := NewSyslogWriter(&out, SyslogOptions{
+		Facility:      FacilityLocal0,
+		FacilityField: "facility",
+		Tag:           "app",
+		Hostname:      "host",
+	})
+	l := New(w, "", Lparsefields|Lmessage)
+
+	l.Print("level=error facility=auth login failed")
+	l.Print("level=debug facility=bogus")
+	l.Print("hello")
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines", len(lines))
+	}
+
+	for i, pri := range []string{"<35>", "<135>", "<134>"} {
+		if !strings.HasPrefix(lines[i], pri) {
+			t.Errorf("line %d: want %s, got %s", i, pri, lines[i])
+		}
+		if !strings.Contains(lines[i], " host app[") {
+			t.Errorf("line %d: missing header: %s", i, lines[i])
+		}
+	}
+
+	if want := `]: {"mesg":"hello"}`; !strings.HasSuffix(lines[2], want) {
+		t.Errorf("want suffix %s, got %s", want, lines[2])
+	}
+}
@@ -0,0 +1,346 @@
+package slog
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// TailOptions configures a Tail.
+type TailOptions struct {
+	// Buffer is the number of entries that are queued for each client.
+	// Entries are dropped for clients that fall behind. Defaults to 256.
+	Buffer int
+
+	// Drops counts the entries that are dropped for clients as overflow.
+	Drops *DropCounter
+}
+
+type tailClient struct {
+	level  Level
+	fields map[string]string
+	ch     chan []byte
+}
+
+// match reports whether the decoded entry has the field values of the client.
+func (c *tailClient) match(fields map[string]interface{}) bool {
+	for key, want := range c.fields {
+		val, ok := fields[key]
+		if !ok || fmt.Sprint(val) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// Tail is a sink that streams the entries written to it to the clients of its
+// HTTP handlers, enabling live log viewers without dependencies. Writes never
+// block on slow clients. It is safe for concurrent use.
+//
+//	tail := slog.NewTail(slog.TailOptions{})
+//	l := slog.New(io.MultiWriter(os.Stderr, tail), "", slog.LstdFlags)
+//	http.Handle("/logs/ws", tail.WebSocket())
+//	http.Handle("/logs/sse", tail.EventStream())
+type Tail struct {
+	opts    TailOptions
+	mu      sync.Mutex
+	clients map[*tailClient]struct{}
+}
+
+// NewTail creates a new Tail.
+func NewTail(opts TailOptions) *Tail {
+	if opts.Buffer <= 0 {
+		opts.Buffer = 256
+	}
+	return &Tail{opts: opts, clients: map[*tailClient]struct{}{}}
+}
+
+// Write sends an entry to the clients whose level it meets.
+// Entries without a level are treated as info.
+func (t *Tail) Write(p []byte) (int, error) {
+	level, ok := lineLevel(p)
+	if !ok {
+		level = LevelInfo
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var line []byte
+	var fields map[string]interface{}
+	for c := range t.clients {
+		if level < c.level {
+			continue
+		}
+		if len(c.fields) > 0 {
+			if fields == nil {
+				if err := json.Unmarshal(p, &fields); err != nil {
+					fields = map[string]interface{}{}
+				}
+			}
+			if !c.match(fields) {
+				continue
+			}
+		}
+		if line == nil {
+			line = append([]byte(nil), p...)
+		}
+		select {
+		case c.ch <- line:
+		default:
+			t.opts.Drops.Add(DropOverflow, level)
+		}
+	}
+	return len(p), nil
+}
+
+// Clients returns the number of connected clients.
+func (t *Tail) Clients() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.clients)
+}
+
+// subscribe registers a client with the level of the level query parameter
+// and the field values of the other query parameters.
+func (t *Tail) subscribe(r *http.Request) (*tailClient, error) {
+	c := &tailClient{ch: make(chan []byte, t.opts.Buffer)}
+	for key, vals := range r.URL.Query() {
+		if key != "level" {
+			if c.fields == nil {
+				c.fields = map[string]string{}
+			}
+			c.fields[key] = vals[0]
+			continue
+		}
+		level, ok := ParseLevel(vals[0])
+		if !ok {
+			return nil, errors.New("unknown level " + vals[0])
+		}
+		c.level = level
+	}
+	t.mu.Lock()
+	t.clients[c] = struct{}{}
+	t.mu.Unlock()
+	return c, nil
+}
+
+func (t *Tail) unsubscribe(c *tailClient) {
+	t.mu.Lock()
+	delete(t.clients, c)
+	t.mu.Unlock()
+}
+
+// WebSocket returns an HTTP handler that upgrades the connection to a WebSocket
+// and sends every entry as a text message. Clients that set the level query
+// parameter, as in /logs/ws?level=warn, only receive the entries at or above the level.
+// Clients that set other query parameters, as in /logs/ws?component=db, only receive
+// the entries whose fields have the values.
+func (t *Tail) WebSocket() http.Handler {
+	return http.HandlerFunc(t.serveWebSocket)
+}
+
+// EventStream returns an HTTP handler that streams every entry as a
+// Server-Sent Event, as a simpler alternative to WebSocket for browsers and curl.
+// The query parameters filter the entries like WebSocket.
+//
+//	curl -N 'http://localhost:8080/logs/sse?level=warn&component=db'
+func (t *Tail) EventStream() http.Handler {
+	return http.HandlerFunc(t.serveEventStream)
+}
+
+func (t *Tail) serveEventStream(w http.ResponseWriter, r *http.Request) {
+	f, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	c, err := t.subscribe(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer t.unsubscribe(c)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	f.Flush()
+
+	buf := make([]byte, 0, 256)
+	for {
+		select {
+		case line := <-c.ch:
+			buf = append(append(buf[:0], "data: "...), bytes.TrimRight(line, "\n")...)
+			buf = append(buf, "\n\n"...)
+			if _, err := w.Write(buf); err != nil {
+				return
+			}
+			f.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// WebSocket opcodes.
+const (
+	wsText  = 0x1
+	wsClose = 0x8
+	wsPing  = 0x9
+	wsPong  = 0xa
+)
+
+func websocketAccept(key string) string {
+	h := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
+func (t *Tail) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") || key == "" {
+		http.Error(w, "expected websocket upgrade", http.StatusBadRequest)
+		return
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return
+	}
+	c, err := t.subscribe(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer t.unsubscribe(c)
+
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + websocketAccept(key) + "\r\n\r\n")
+	if rw.Flush() != nil {
+		return
+	}
+
+	ws := &wsConn{w: rw.Writer}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ws.readLoop(rw.Reader)
+	}()
+
+	for {
+		select {
+		case line := <-c.ch:
+			if ws.writeFrame(wsText, line) != nil {
+				return
+			}
+		case <-done:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// wsConn serializes the frames written by the server.
+type wsConn struct {
+	mu sync.Mutex
+	w  *bufio.Writer
+}
+
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var hdr [10]byte
+	hdr[0] = 0x80 | opcode
+	n := 2
+	switch size := len(payload); {
+	case size < 126:
+		hdr[1] = byte(size)
+	case size <= 0xffff:
+		hdr[1] = 126
+		binary.BigEndian.PutUint16(hdr[2:], uint16(size))
+		n = 4
+	default:
+		hdr[1] = 127
+		binary.BigEndian.PutUint64(hdr[2:], uint64(size))
+		n = 10
+	}
+	_, _ = c.w.Write(hdr[:n])
+	_, _ = c.w.Write(payload)
+	return c.w.Flush()
+}
+
+// readLoop reads the frames sent by the client until it closes the connection.
+// Pings are answered and data frames are discarded.
+func (c *wsConn) readLoop(r *bufio.Reader) {
+	var hdr [8]byte
+	for {
+		if _, err := io.ReadFull(r, hdr[:2]); err != nil {
+			return
+		}
+		opcode := hdr[0] & 0xf
+		masked := hdr[1]&0x80 != 0
+		size := uint64(hdr[1] & 0x7f)
+		switch size {
+		case 126:
+			if _, err := io.ReadFull(r, hdr[:2]); err != nil {
+				return
+			}
+			size = uint64(binary.BigEndian.Uint16(hdr[:2]))
+		case 127:
+			if _, err := io.ReadFull(r, hdr[:8]); err != nil {
+				return
+			}
+			size = binary.BigEndian.Uint64(hdr[:8])
+		}
+		var mask [4]byte
+		if masked {
+			if _, err := io.ReadFull(r, mask[:]); err != nil {
+				return
+			}
+		}
+
+		if opcode < wsClose {
+			if _, err := io.CopyN(io.Discard, r, int64(size)); err != nil {
+				return
+			}
+			continue
+		}
+		if size > 125 {
+			return
+		}
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return
+		}
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+		switch opcode {
+		case wsPing:
+			if c.writeFrame(wsPong, payload) != nil {
+				return
+			}
+		case wsClose:
+			_ = c.writeFrame(wsClose, payload)
+			return
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package slog
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebsocketAccept(t *testing.T) {
+	// example from RFC 6455
+	if s := websocketAccept("dGhlIHNhbXBsZSBub25jZQ=="); s != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatal(s)
+	}
+}
+
+func dialTail(t *testing.T, addr, query string) (net.Conn, *bufio.Reader) {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = io.WriteString(conn, "GET /?"+query+" HTTP/1.1\r\nHost: x\r\n"+
+		"Upgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n")
+	r := bufio.NewReader(conn)
+	res, err := http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatal(res.Status)
+	}
+	return conn, r
+}
+
+func readFrame(t *testing.T, r *bufio.Reader) (byte, string) {
+	t.Helper()
+	var hdr [2]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		t.Fatal(err)
+	}
+	payload := make([]byte, hdr[1]&0x7f)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		t.Fatal(err)
+	}
+	return hdr[0] & 0xf, string(payload)
+}
+
+func TestTailWebSocket(t *testing.T) {
+	tail := NewTail(TailOptions{})
+	srv := httptest.NewServer(tail.WebSocket())
+	defer srv.Close()
+
+	conn, r := dialTail(t, srv.Listener.Addr().String(), "level=warn")
+	defer conn.Close()
+
+	for tail.Clients() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	l := New(tail, "", Lparsefields)
+	l.Print("level=info a=1")
+	l.Print("level=error a=2")
+
+	if op, s := readFrame(t, r); op != wsText || s != `{"level":"error","a":2}`+"\n" {
+		t.Fatal(op, s)
+	}
+
+	// masked ping with payload "hi"
+	_, _ = conn.Write([]byte{0x89, 0x82, 1, 2, 3, 4, 'h' ^ 1, 'i' ^ 2})
+	if op, s := readFrame(t, r); op != wsPong || s != "hi" {
+		t.Fatal(op, s)
+	}
+
+	_, _ = conn.Write([]byte{0x88, 0x80, 0, 0, 0, 0})
+	if op, _ := readFrame(t, r); op != wsClose {
+		t.Fatal(op)
+	}
+	for tail.Clients() != 0 {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestTailOverflow(t *testing.T) {
+	drops := NewDropCounter()
+	tail := NewTail(TailOptions{Buffer: 1, Drops: drops})
+	c, err := tail.subscribe(httptest.NewRequest("GET", "/", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = io.WriteString(tail, `{"a":1}`+"\n")
+	_, _ = io.WriteString(tail, `{"a":2}`+"\n")
+	if s := string(<-c.ch); !strings.Contains(s, `"a":1`) || drops.Total() != 1 {
+		t.Fatal(s, drops.Total())
+	}
+
+	rec := httptest.NewRecorder()
+	tail.WebSocket().ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatal(rec.Code)
+	}
+}
+
+func TestTailEventStream(t *testing.T) {
+	tail := NewTail(TailOptions{})
+	srv := httptest.NewServer(tail.EventStream())
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "?level=warn&component=db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatal(ct)
+	}
+
+	for tail.Clients() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	l := New(tail, "", Lparsefields)
+	l.Print("level=error component=api a=1")
+	l.Print("level=info component=db a=2")
+	l.Print("level=warn component=db a=3")
+
+	r := bufio.NewReader(res.Body)
+	line, err := r.ReadString('\n')
+	if err != nil || line != `data: {"level":"warn","component":"db","a":3}`+"\n" {
+		t.Fatal(line, err)
+	}
+	if line, _ := r.ReadString('\n'); line != "\n" {
+		t.Fatal(line)
+	}
+
+	res2, err := http.Get(srv.URL + "?level=bogus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res2.Body.Close()
+	if res2.StatusCode != http.StatusBadRequest {
+		t.Fatal(res2.Status)
+	}
+}
@@ -0,0 +1,36 @@
+package slog
+
+import "time"
+
+// looksLikeRFC3339 is a cheap test that avoids parsing most strings that are not timestamps.
+func looksLikeRFC3339(s string) bool {
+	return len(s) >= len("2006-01-02T15:04:05Z") && s[4] == '-' && s[7] == '-' &&
+		(s[10] == 'T' || s[10] == 't') && s[13] == ':'
+}
+
+// TimeValues recognizes string values that are RFC 3339 timestamps,
+// such as deadline=2024-06-01T12:00:00+02:00, and adds a field with the key
+// followed by .type and the value timestamp after them, so that downstream
+// systems map them to date-typed columns. If utc is true, the timestamps are
+// also normalized to UTC in the RFC 3339 format with nanoseconds, so that
+// they sort lexically.
+func TimeValues(utc bool) Option {
+	return BeforeWrite(func(e *Entry) bool {
+		for i := 0; i < len(e.Fields); i++ {
+			f := &e.Fields[i]
+			if f.Value.kind != KindString || !looksLikeRFC3339(f.Value.str) {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339Nano, f.Value.str)
+			if err != nil {
+				continue
+			}
+			if utc {
+				f.Value = StringValue(t.UTC().Format(time.RFC3339Nano))
+			}
+			e.insert(i+1, Field{f.Key + ".type", StringValue("timestamp")})
+			i++
+		}
+		return true
+	})
+}
@@ -0,0 +1,20 @@
+package slog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTimeValues(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields, TimeValues(true))
+	l.Print("deadline=2024-06-01T12:00:00.5+02:00 day=2024-06-01 bad=2024-13-01T00:00:00Z")
+	l = New(&b, "", Lparsefields, TimeValues(false))
+	l.Print("deadline=2024-06-01T12:00:00+02:00")
+
+	want := `{"deadline":"2024-06-01T10:00:00.5Z","deadline.type":"timestamp","day":"2024-06-01","bad":"2024-13-01T00:00:00Z"}` + "\n" +
+		`{"deadline":"2024-06-01T12:00:00+02:00","deadline.type":"timestamp"}` + "\n"
+	if b.String() != want {
+		t.Fatal(b.String())
+	}
+}
@@ -0,0 +1,33 @@
+package slog
+
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// appendTraceparent splits a W3C traceparent header value of the form
+// version-traceid-spanid-flags into the trace_id, span_id and trace_flags fields.
+// Invalid values are ignored.
+func appendTraceparent(fields []Field, val string) []Field {
+	const size = 2 + 1 + 32 + 1 + 16 + 1 + 2
+	if len(val) < size || (len(val) > size && val[size] != '-') || val[2] != '-' || val[35] != '-' || val[52] != '-' {
+		return fields
+	}
+
+	version, traceID, spanID, flags := val[0:2], val[3:35], val[36:52], val[53:55]
+	if !isLowerHex(version) || version == "ff" || !isLowerHex(traceID) || !isLowerHex(spanID) || !isLowerHex(flags) {
+		return fields
+	} else if traceID == "00000000000000000000000000000000" || spanID == "0000000000000000" {
+		return fields
+	}
+
+	return append(fields,
+		Field{"trace_id", StringValue(traceID)},
+		Field{"span_id", StringValue(spanID)},
+		Field{"trace_flags", StringValue(flags)},
+	)
+}
@@ -0,0 +1,20 @@
+package slog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTraceparent(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields)
+	l.Println("handled traceparent=00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	l.Println("handled traceparent=00-00000000000000000000000000000000-00f067aa0ba902b7-01")
+
+	exp := "{\"traceparent\":\"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01\"," +
+		"\"trace_id\":\"4bf92f3577b34da6a3ce929d0e0e4736\",\"span_id\":\"00f067aa0ba902b7\",\"trace_flags\":\"01\"}\n" +
+		"{\"traceparent\":\"00-00000000000000000000000000000000-00f067aa0ba902b7-01\"}\n"
+	if b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
@@ -0,0 +1,64 @@
+package slog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Transformer transforms the value of a field.
+type Transformer func(Value) Value
+
+// HashSHA256 replaces a value by the hex encoded SHA-256 hash of its string representation.
+func HashSHA256(v Value) Value {
+	sum := sha256.Sum256([]byte(v.String()))
+	return StringValue(hex.EncodeToString(sum[:]))
+}
+
+// MaskAllBut returns a Transformer that replaces all but the last n characters
+// of a value by asterisks. Masking with n = 4 is customary for card numbers.
+func MaskAllBut(n int) Transformer {
+	return func(v Value) Value {
+		r := []rune(v.String())
+		for i := 0; i < len(r)-n; i++ {
+			r[i] = '*'
+		}
+		return StringValue(string(r))
+	}
+}
+
+// Transform registers transformers that are applied to the values of
+// the fields with the given keys. The value in the message and in the raw line
+// stored by Lraw is replaced as well, so that the original value does not
+// appear in the output.
+func Transform(transformers map[string]Transformer) Option {
+	return BeforeWrite(func(e *Entry) bool {
+		for i := range e.Fields {
+			f := &e.Fields[i]
+			if tf, ok := transformers[f.Key]; ok {
+				val := tf(f.Value)
+				e.Message = replaceFieldText(e.Message, f.Key, f.Value, val)
+				e.Raw = replaceFieldText(e.Raw, f.Key, f.Value, val)
+				f.Value = val
+			}
+		}
+		return true
+	})
+}
+
+// replaceFieldText replaces the text of the value of the first key=value pair
+// in the message whose value parses to old by val. The scanned text is replaced,
+// so that non-canonical literals such as 00123 or 1.50 do not survive.
+func replaceFieldText(mesg, key string, old, val Value) string {
+	for s := mesg; len(s) > 0; {
+		z, k, v, quote, ok := scanKeyVals(s)
+		if ok && k == key && parseValue(v, quote) == old {
+			end := len(mesg) - len(z)
+			if quote {
+				end--
+			}
+			return mesg[:end-len(v)] + val.String() + mesg[end:]
+		}
+		s = z
+	}
+	return mesg
+}
@@ -0,0 +1,46 @@
+package slog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTransform(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields|Lmessage, Transform(map[string]Transformer{
+		"email": HashSHA256,
+		"card":  MaskAllBut(4),
+	}))
+	l.Println("payment email=\"bob@example.com\" card=4111111111111111")
+
+	exp := "{\"mesg\":\"payment email=\\\"5ff860bf1190596c7188ab851db691f0f3169c453936e9e1eba2f9a47f7a0018\\\" card=************1111\"," +
+		"\"email\":\"5ff860bf1190596c7188ab851db691f0f3169c453936e9e1eba2f9a47f7a0018\",\"card\":\"************1111\"}\n"
+	if b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
+
+func TestTransformLiterals(t *testing.T) {
+	var b bytes.Buffer
+	mask := MaskAllBut(0)
+	l := New(&b, "", Lparsefields|Lmessage, Transform(map[string]Transformer{
+		"id": mask, "n": mask, "f": mask, "s": mask,
+	}))
+	l.Println("id=00123 n=0x10 f=1.50 s=\"a b\" x=1")
+
+	exp := "{\"mesg\":\"id=***** n=** f=*** s=\\\"***\\\" x=1\",\"id\":\"*****\",\"n\":\"**\",\"f\":\"***\",\"s\":\"***\",\"x\":1}\n"
+	if b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
+
+func TestTransformRaw(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "app: ", Lparsefields|Lraw, Transform(map[string]Transformer{"card": MaskAllBut(4)}))
+	l.Println("payment card=4111111111111111")
+
+	exp := "{\"prfx\":\"app\",\"raw\":\"app: payment card=************1111\",\"card\":\"************1111\"}\n"
+	if b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
@@ -0,0 +1,170 @@
+package slog
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+)
+
+// TransportOptions configures the HTTP client transport.
+type TransportOptions struct {
+	// Transport is the transport that sends the requests.
+	// Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// RequestIDHeader is the header that carries the request id taken
+	// from the request context, if any. Defaults to DefaultRequestIDHeader.
+	RequestIDHeader string
+
+	// Retries is the maximum number of times that an idempotent request
+	// is retried after a network error or a 502, 503 or 504 response.
+	// Requests are not retried if zero or negative.
+	Retries int
+
+	// Backoff is the delay before the first retry, which doubles with every retry.
+	// Defaults to 100 milliseconds.
+	Backoff time.Duration
+
+	// Clock tells the time used to measure the duration of requests.
+	// Defaults to SystemClock.
+	Clock Clock
+
+	// BodyLimit is the number of bytes of the request and response bodies
+	// that are logged in the reqbody and resbody fields. The request body is
+	// only logged if the request has GetBody. The beginning of the response body
+	// is read before RoundTrip returns, which delays streaming responses.
+	// Truncated bodies end with an ellipsis. Bodies are not logged if zero.
+	BodyLimit int
+
+	// BodyTypes lists the content types of the bodies that are logged.
+	// A type ending in a slash matches all subtypes. Defaults to DefaultBodyTypes.
+	BodyTypes []string
+}
+
+type transport struct {
+	l    *log.Logger
+	opts TransportOptions
+}
+
+func isIdempotent(r *http.Request) bool {
+	switch r.Method {
+	case "GET", "HEAD", "OPTIONS", "TRACE", "PUT", "DELETE":
+		return r.Body == nil || r.Body == http.NoBody || r.GetBody != nil
+	}
+	return false
+}
+
+func shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch res.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// rewind returns a copy of the request with a fresh body for a retry.
+func rewind(r *http.Request) (*http.Request, error) {
+	if r.GetBody == nil {
+		return r, nil
+	}
+	body, err := r.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	r = r.Clone(r.Context())
+	r.Body = body
+	return r, nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	clock := clockOrSystem(t.opts.Clock)
+	start := clock.Now()
+
+	ctx := r.Context()
+	if id := RequestID(ctx); id != "" && r.Header.Get(t.opts.RequestIDHeader) == "" {
+		r = r.Clone(ctx)
+		r.Header.Set(t.opts.RequestIDHeader, id)
+	}
+
+	var res *http.Response
+	var err error
+	var retries int
+	for backoff := t.opts.Backoff; ; backoff *= 2 {
+		res, err = t.opts.Transport.RoundTrip(r)
+		if retries >= t.opts.Retries || !isIdempotent(r) || !shouldRetry(res, err) {
+			break
+		}
+		if res != nil {
+			res.Body.Close()
+		}
+		var next *http.Request
+		if next, err = rewind(r); err == nil {
+			err = sleepContext(ctx, backoff)
+		}
+		if err != nil {
+			res = nil
+			break
+		}
+		r = next
+		retries++
+	}
+
+	var reqBody, resBody *bodyCapture
+	if t.opts.BodyLimit > 0 {
+		if r.GetBody != nil && bodyTypeAllowed(r.Header.Get("Content-Type"), t.opts.BodyTypes) {
+			if body, err := r.GetBody(); err == nil {
+				_, reqBody = peekBody(body, t.opts.BodyLimit)
+				body.Close()
+			}
+		}
+		if res != nil && bodyTypeAllowed(res.Header.Get("Content-Type"), t.opts.BodyTypes) {
+			res.Body, resBody = peekBody(res.Body, t.opts.BodyLimit)
+		}
+	}
+
+	l := FromContext(ctx, t.l)
+	if err != nil {
+		l.Printf("method=%s url=%s error=%s durms=%.3f retries=%d%s",
+			r.Method, quoteValue(r.URL.String()), quoteValue(err.Error()), msSince(clock, start), retries,
+			reqBody.field("reqbody"))
+		return nil, err
+	}
+
+	l.Printf("method=%s url=%s status=%d durms=%.3f retries=%d%s%s",
+		r.Method, quoteValue(r.URL.String()), res.StatusCode, msSince(clock, start), retries,
+		reqBody.field("reqbody"), resBody.field("resbody"))
+	return res, nil
+}
+
+// Transport wraps an HTTP client transport to log one entry per outbound request
+// with the fields method, url, status, durms and retries, or error instead of
+// status if the request failed. The request id carried by the request context
+// is sent in the request header and stored in the reqid field, so that the
+// requests made by a handler can be correlated with the entry logged by Middleware.
+func Transport(l *log.Logger, opts TransportOptions) http.RoundTripper {
+	if opts.Transport == nil {
+		opts.Transport = http.DefaultTransport
+	}
+	if opts.RequestIDHeader == "" {
+		opts.RequestIDHeader = DefaultRequestIDHeader
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = 100 * time.Millisecond
+	}
+	return &transport{l, opts}
+}
@@ -0,0 +1,112 @@
+package slog
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTransport(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls++; calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(r.Header.Get(DefaultRequestIDHeader)))
+	}))
+	defer srv.Close()
+
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields)
+	client := http.Client{Transport: Transport(l, TransportOptions{Retries: 3, Backoff: time.Millisecond})}
+
+	req, _ := http.NewRequest("GET", srv.URL+"/x", nil)
+	req = req.WithContext(ContextWithRequestID(context.Background(), "abc"))
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	var body bytes.Buffer
+	body.ReadFrom(res.Body)
+	if body.String() != "abc" {
+		t.Fatal("request id not propagated:", body.String())
+	}
+
+	for _, want := range []string{`"method":"GET"`, `"status":200`, `"retries":2`, `"reqid":"abc"`} {
+		if !strings.Contains(b.String(), want) {
+			t.Error(want, b.String())
+		}
+	}
+}
+
+func TestTransportNegativeRetries(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields)
+	client := http.Client{Transport: Transport(l, TransportOptions{Retries: -1, Backoff: time.Millisecond})}
+
+	res, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusServiceUnavailable || calls != 1 {
+		t.Fatal(res.StatusCode, calls)
+	} else if !strings.Contains(b.String(), `"retries":0`) {
+		t.Fatal(b.String())
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields)
+	client := http.Client{Transport: Transport(l, TransportOptions{})}
+
+	if _, err := client.Get("http://127.0.0.1:1/"); err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(b.String(), `"error":`) || !strings.Contains(b.String(), `"retries":0`) {
+		t.Fatal(b.String())
+	}
+}
+
+func TestTransportBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		io.Copy(w, r.Body)
+	}))
+	defer srv.Close()
+
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields)
+	client := http.Client{Transport: Transport(l, TransportOptions{BodyLimit: 5})}
+
+	res, err := client.Post(srv.URL, "text/plain", strings.NewReader("hello world"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+
+	if string(body) != "hello world" {
+		t.Fatal("body not preserved:", string(body))
+	}
+	if !strings.Contains(b.String(), `"reqbody":"hello..."`) || !strings.Contains(b.String(), `"resbody":"hello..."`) {
+		t.Fatal(b.String())
+	}
+}
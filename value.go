@@ -0,0 +1,223 @@
+package slog
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Kind is the type of a Value.
+type Kind int
+
+// Kinds of values.
+const (
+	KindString Kind = iota
+	KindInt
+	KindFloat
+	KindBool
+	KindNull
+)
+
+// Value is the typed value of a field.
+// The zero Value is the empty string.
+type Value struct {
+	kind Kind
+	str  string
+	num  uint64
+}
+
+// StringValue returns a string Value.
+func StringValue(s string) Value { return Value{kind: KindString, str: s} }
+
+// IntValue returns an integer Value.
+func IntValue(i int64) Value { return Value{kind: KindInt, num: uint64(i)} }
+
+// FloatValue returns a floating point Value.
+func FloatValue(f float64) Value { return Value{kind: KindFloat, num: math.Float64bits(f)} }
+
+// BoolValue returns a boolean Value.
+func BoolValue(b bool) Value {
+	var n uint64
+	if b {
+		n = 1
+	}
+	return Value{kind: KindBool, num: n}
+}
+
+// NullValue returns the null Value.
+func NullValue() Value { return Value{kind: KindNull} }
+
+// Kind returns the type of the value.
+func (v Value) Kind() Kind { return v.kind }
+
+// Int returns the value as an integer. Floats are truncated.
+func (v Value) Int() int64 {
+	switch v.kind {
+	case KindInt, KindBool:
+		return int64(v.num)
+	case KindFloat:
+		return int64(math.Float64frombits(v.num))
+	}
+	return 0
+}
+
+// Float returns the value as a floating point number.
+func (v Value) Float() float64 {
+	switch v.kind {
+	case KindInt, KindBool:
+		return float64(int64(v.num))
+	case KindFloat:
+		return math.Float64frombits(v.num)
+	}
+	return 0
+}
+
+// Bool returns the value as a boolean.
+func (v Value) Bool() bool { return v.kind == KindBool && v.num != 0 }
+
+// String returns the value formatted as it appears in the log message.
+func (v Value) String() string {
+	switch v.kind {
+	case KindInt:
+		return strconv.FormatInt(int64(v.num), 10)
+	case KindFloat:
+		return strconv.FormatFloat(math.Float64frombits(v.num), 'f', -1, 64)
+	case KindBool:
+		return strconv.FormatBool(v.num != 0)
+	case KindNull:
+		return "null"
+	}
+	return v.str
+}
+
+// Any returns the value as a string, int64, float64, bool or nil.
+func (v Value) Any() interface{} {
+	switch v.kind {
+	case KindInt:
+		return int64(v.num)
+	case KindFloat:
+		return math.Float64frombits(v.num)
+	case KindBool:
+		return v.num != 0
+	case KindNull:
+		return nil
+	}
+	return v.str
+}
+
+// parseValue infers the type of an unquoted value.
+func parseValue(val string, quote bool) Value {
+	// string
+	if quote {
+		return StringValue(val)
+	}
+
+	// keyword
+	switch val {
+	case "true":
+		return BoolValue(true)
+	case "false":
+		return BoolValue(false)
+	case "null", "<nil>":
+		return NullValue()
+	}
+
+	// number
+	if strings.ContainsAny(val, "0123456789") {
+		if strings.IndexByte(val, '.') >= 0 {
+			if flt, err := strconv.ParseFloat(val, 64); err == nil {
+				return FloatValue(flt)
+			}
+		} else if !hasLeadingZero(val) {
+			if i, err := strconv.ParseInt(val, 0, 64); err == nil {
+				return IntValue(i)
+			}
+		}
+	}
+
+	// string
+	return StringValue(val)
+}
+
+// hasLeadingZero reports whether s is a decimal number with a leading zero,
+// such as a zip code or an identifier, which would otherwise be parsed as octal.
+func hasLeadingZero(s string) bool {
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		s = s[1:]
+	}
+	if len(s) < 2 || s[0] != '0' {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func appendValue(dst []byte, v Value, col colorFunc, quote quoteFunc) []byte {
+	switch v.kind {
+	case KindInt:
+		return appendInt(dst, int64(v.num))
+	case KindFloat:
+		return appendFloat(dst, math.Float64frombits(v.num))
+	case KindBool:
+		return strconv.AppendBool(dst, v.num != 0)
+	case KindNull:
+		return append(dst, "null"...)
+	}
+	return appendQuote(dst, v.str, col, quote)
+}
+
+// AnyValue converts a Go value to a Value. Integers, floats, booleans, strings
+// and nil map to the respective kinds, and other values are formatted with fmt.
+func AnyValue(v interface{}) Value {
+	switch v := v.(type) {
+	case nil:
+		return NullValue()
+	case Value:
+		return v
+	case string:
+		return StringValue(v)
+	case bool:
+		return BoolValue(v)
+	case int:
+		return IntValue(int64(v))
+	case int8:
+		return IntValue(int64(v))
+	case int16:
+		return IntValue(int64(v))
+	case int32:
+		return IntValue(int64(v))
+	case int64:
+		return IntValue(v)
+	case uint:
+		return uintValue(uint64(v))
+	case uint8:
+		return IntValue(int64(v))
+	case uint16:
+		return IntValue(int64(v))
+	case uint32:
+		return IntValue(int64(v))
+	case uint64:
+		return uintValue(v)
+	case float32:
+		return FloatValue(float64(v))
+	case float64:
+		return FloatValue(v)
+	case error:
+		return StringValue(v.Error())
+	case fmt.Stringer:
+		return StringValue(v.String())
+	}
+	return StringValue(fmt.Sprint(v))
+}
+
+func uintValue(u uint64) Value {
+	if u > math.MaxInt64 {
+		return StringValue(strconv.FormatUint(u, 10))
+	}
+	return IntValue(int64(u))
+}
@@ -0,0 +1,158 @@
+package slog
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type vmodule struct {
+	pattern string
+	level   int
+}
+
+// Verbosity holds the verbosity levels of a program like the -v and -vmodule
+// flags of glog: a global level and levels for the loggers whose prefix matches
+// a pattern. It is safe for concurrent use. The zero value has level 0.
+type Verbosity struct {
+	level   int64
+	mu      sync.RWMutex
+	modules []vmodule
+}
+
+// DefaultVerbosity is the verbosity used by V.
+var DefaultVerbosity = &Verbosity{}
+
+// Level returns the global level.
+func (v *Verbosity) Level() int {
+	return int(atomic.LoadInt64(&v.level))
+}
+
+// Set changes the global level.
+func (v *Verbosity) Set(level int) {
+	atomic.StoreInt64(&v.level, int64(level))
+}
+
+// SetModules sets the levels of the loggers whose prefix matches a pattern,
+// given as a comma separated list of pattern=level, such as "db=2,api.*=1".
+// The patterns are matched with path.Match against the prefix of the logger
+// with its components joined by dots, as in the prfx field.
+// The first matching pattern takes precedence over the global level.
+// An empty spec removes all patterns.
+func (v *Verbosity) SetModules(spec string) error {
+	var modules []vmodule
+	for _, s := range strings.Split(spec, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		i := strings.LastIndexByte(s, '=')
+		if i == -1 {
+			return errors.New("slog: vmodule: missing level in " + strconv.Quote(s))
+		}
+		level, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return errors.New("slog: vmodule: invalid level in " + strconv.Quote(s))
+		}
+		if _, err := path.Match(s[:i], ""); err != nil {
+			return errors.New("slog: vmodule: invalid pattern in " + strconv.Quote(s))
+		}
+		modules = append(modules, vmodule{s[:i], level})
+	}
+	v.mu.Lock()
+	v.modules = modules
+	v.mu.Unlock()
+	return nil
+}
+
+// Enabled reports whether level n is enabled for a logger with the prefix.
+func (v *Verbosity) Enabled(prefix string, n int) bool {
+	v.mu.RLock()
+	modules := v.modules
+	v.mu.RUnlock()
+	if len(modules) > 0 {
+		name := prefixPath(prefix)
+		for _, m := range modules {
+			if ok, _ := path.Match(m.pattern, name); ok {
+				return n <= m.level
+			}
+		}
+	}
+	return n <= v.Level()
+}
+
+// V returns a Verbose that logs to l if level n is enabled for the prefix of l.
+// The default logger is used if l is nil.
+//
+//	v.V(l, 2).Printf("cache miss key=%s", key)
+func (v *Verbosity) V(l *log.Logger, n int) Verbose {
+	if l == nil {
+		l = Default()
+	}
+	if !v.Enabled(l.Prefix(), n) {
+		return Verbose{}
+	}
+	return Verbose{l, n}
+}
+
+// V returns a Verbose that logs to the default logger if level n is enabled by DefaultVerbosity.
+//
+//	if slog.V(2).Enabled() {
+//		slog.V(2).Print("details ", expensive())
+//	}
+func V(n int) Verbose {
+	return DefaultVerbosity.V(nil, n)
+}
+
+// Verbose logs entries of a verbosity level, which is stored in the v field,
+// if the level is enabled. Its methods do nothing otherwise.
+type Verbose struct {
+	l *log.Logger
+	n int
+}
+
+// Enabled reports whether the level is enabled.
+func (v Verbose) Enabled() bool { return v.l != nil }
+
+func (v Verbose) output(s string) {
+	if len(s) > 0 && s[len(s)-1] == '\n' {
+		s = s[:len(s)-1]
+	}
+	_ = v.l.Output(3, s+" v="+strconv.Itoa(v.n))
+}
+
+// Print logs like fmt.Print if the level is enabled.
+func (v Verbose) Print(args ...interface{}) {
+	if v.l != nil {
+		v.output(fmt.Sprint(args...))
+	}
+}
+
+// Printf logs like fmt.Printf if the level is enabled.
+func (v Verbose) Printf(format string, args ...interface{}) {
+	if v.l != nil {
+		v.output(fmt.Sprintf(format, args...))
+	}
+}
+
+// Println logs like fmt.Println if the level is enabled.
+func (v Verbose) Println(args ...interface{}) {
+	if v.l != nil {
+		v.output(fmt.Sprintln(args...))
+	}
+}
+
+// Printw logs like Printw if the level is enabled.
+func (v Verbose) Printw(msg string, kv ...interface{}) {
+	if v.l != nil {
+		v.output(formatw(0, msg, kv))
+	}
+}
+
+func init() {
+	registerWrappers(Verbose.output, Verbose.Print, Verbose.Printf, Verbose.Println, Verbose.Printw)
+}
@@ -0,0 +1,39 @@
+package slog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestVerbosity(t *testing.T) {
+	var v Verbosity
+	v.Set(1)
+	if err := v.SetModules("api.db=3, cache*=0"); err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	api := New(&b, "api: ", Lparsefields|Lmessage|Lfuncname)
+	db := Named(api, "db")
+	cache := New(&b, "cache: ", Lparsefields)
+
+	v.V(api, 1).Printf("a=%d", 1)
+	v.V(api, 2).Print("hidden")
+	v.V(db, 3).Printw("query", "rows", 2)
+	v.V(cache, 1).Print("hidden")
+
+	want := `{"prfx":"api","func":"slog.TestVerbosity","mesg":"a=1 v=1","a":1,"v":1}` + "\n" +
+		`{"prfx":"api.db","func":"slog.TestVerbosity","mesg":"query rows=2 v=3","rows":2,"v":3}` + "\n"
+	if b.String() != want {
+		t.Fatal(b.String())
+	}
+	if v.V(cache, 0).Enabled() != true || v.V(cache, 1).Enabled() {
+		t.Fatal("cache verbosity")
+	}
+
+	for _, spec := range []string{"db", "db=x", "[=1"} {
+		if err := v.SetModules(spec); err == nil {
+			t.Fatal(spec)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package slog
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+)
+
+// With returns a logger derived from l that adds the alternating keys and values
+// to every entry as fields. A key without a value is given the null value.
+// The derived logger shares the output writer and options with l.
+// If l does not write to a structured writer, the pairs are appended to the prefix
+// as key=value text instead.
+func With(l *log.Logger, kv ...interface{}) *log.Logger {
+	fields := make([]Field, 0, (len(kv)+1)/2)
+	for i := 0; i < len(kv); i += 2 {
+		key := fmt.Sprint(kv[i])
+		val := NullValue()
+		if i+1 < len(kv) {
+			val = AnyValue(kv[i+1])
+		}
+		fields = append(fields, Field{key, val})
+	}
+
+	if lw, ok := l.Writer().(*logwriter); ok {
+		return log.New(lw.with(fields...), l.Prefix(), l.Flags())
+	}
+
+	var prefix strings.Builder
+	prefix.WriteString(l.Prefix())
+	for _, f := range fields {
+		prefix.WriteString(f.Key)
+		prefix.WriteByte('=')
+		prefix.WriteString(quoteValue(f.Value.String()))
+		prefix.WriteByte(' ')
+	}
+	return log.New(l.Writer(), prefix.String(), l.Flags())
+}
+
+// Named returns a logger derived from l whose prefix is extended with name,
+// such that loggers derived from a logger with prefix "api: " have
+// prefixes like "api: db: ". The derived logger shares the output writer
+// and options with l.
+func Named(l *log.Logger, name string) *log.Logger {
+	prefix := l.Prefix() + name + ": "
+	if lw, ok := l.Writer().(*logwriter); ok {
+		lw = lw.with()
+		lw.prefix = prefix
+		return log.New(lw, prefix, l.Flags())
+	}
+	return log.New(l.Writer(), prefix, l.Flags())
+}
+
+// NewErrorLogger creates a logger for error-only outputs such as http.Server.ErrorLog,
+// whose entries are tagged with the error level in the level field and the
+// component http in the component field. It uses LstdFlags and the options.
+//
+//	srv := &http.Server{ErrorLog: slog.NewErrorLogger(os.Stderr)}
+func NewErrorLogger(w io.Writer, opts ...Option) *log.Logger {
+	opts = append(opts[:len(opts):len(opts)], func(l *logwriter) {
+		l.static = append(l.static,
+			Field{"level", StringValue(LevelError.String())},
+			Field{"component", StringValue("http")})
+	})
+	return New(w, "", LstdFlags, opts...)
+}
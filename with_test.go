@@ -0,0 +1,63 @@
+package slog
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"testing"
+)
+
+func TestWith(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "", Lparsefields|Lmessage)
+	sub := With(l, "component", "db", "shard", 3, "err", errors.New("x y"), "dangling")
+	With(sub, "nested", true).Println("query a=1")
+	l.Println("plain")
+
+	exp := "{\"mesg\":\"query a=1\",\"a\":1,\"component\":\"db\",\"shard\":3,\"err\":\"x y\",\"dangling\":null,\"nested\":true}\n" +
+		"{\"mesg\":\"plain\"}\n"
+	if b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
+
+func TestWithPlainLogger(t *testing.T) {
+	var b bytes.Buffer
+	With(log.New(&b, "app: ", 0), "a", "x y").Println("hello")
+	if b.String() != "app: a=\"x y\" hello\n" {
+		t.Fatal(b.String())
+	}
+}
+
+func TestNamed(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, "api: ", Lmessage)
+	Named(l, "db").Println("hello")
+	Named(New(&b, "", Lmessage|log.Lmsgprefix), "db").Println("world")
+
+	exp := "{\"prfx\":\"api.db\",\"mesg\":\"hello\"}\n{\"mesg\":\"db: world\"}\n"
+	if b.String() != exp {
+		t.Fatal(b.String())
+	}
+}
+
+func TestNewErrorLogger(t *testing.T) {
+	var b bytes.Buffer
+	NewErrorLogger(&b).Print("http: TLS handshake error")
+
+	var e Entry
+	if err := NewDecoder(&b).Decode(&e); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := e.Get("level"); v.String() != "error" {
+		t.Fatal(e)
+	} else if _, ok := e.Get("levl"); ok {
+		t.Fatal(e)
+	}
+	if v, _ := e.Get("component"); v.String() != "http" {
+		t.Fatal(e)
+	}
+	if e.Message != "http: TLS handshake error" || e.Time.IsZero() {
+		t.Fatal(e)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !safe && go1.20
+// +build !safe,go1.20
+
+package slog
+
+import "unsafe"
+
+// zcstring converts p to a string without copying. The string must not be used after p is modified.
+func zcstring(p []byte) string { return unsafe.String(unsafe.SliceData(p), len(p)) }
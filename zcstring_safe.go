@@ -0,0 +1,7 @@
+//go:build safe
+// +build safe
+
+package slog
+
+// zcstring converts p to a string by copying it.
+func zcstring(p []byte) string { return string(p) }